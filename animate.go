@@ -0,0 +1,68 @@
+package win32utils
+
+import "golang.org/x/sys/windows"
+
+// Flags for AnimateWindow.
+const (
+	AW_HOR_POSITIVE uint32 = 0x00000001
+	AW_HOR_NEGATIVE uint32 = 0x00000002
+	AW_VER_POSITIVE uint32 = 0x00000004
+	AW_VER_NEGATIVE uint32 = 0x00000008
+	AW_CENTER       uint32 = 0x00000010
+	AW_HIDE         uint32 = 0x00010000
+	AW_ACTIVATE     uint32 = 0x00020000
+	AW_SLIDE        uint32 = 0x00040000
+	AW_BLEND        uint32 = 0x00080000
+)
+
+const LWA_ALPHA uint32 = 0x00000002
+
+// AnimateWindow enables you to produce special effects when showing or hiding windows.
+// AW_BLEND needs a top-level window with the WS_EX_LAYERED extended style;
+// the style is added automatically when it is missing.
+func AnimateWindow(hwnd windows.HWND, timeMs uint32, flags uint32) error {
+	if flags&AW_BLEND != 0 {
+		err := ensureLayered(hwnd)
+		if err != nil {
+			return err
+		}
+	}
+
+	r1, _, err := User32.NewProc("AnimateWindow").Call(uintptr(hwnd), uintptr(timeMs), uintptr(flags))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// FadeInWindow shows and activates hwnd with a fade effect.
+func FadeInWindow(hwnd windows.HWND, durationMs uint32) error {
+	return AnimateWindow(hwnd, durationMs, AW_BLEND|AW_ACTIVATE)
+}
+
+// FadeOutWindow hides hwnd with a fade effect.
+func FadeOutWindow(hwnd windows.HWND, durationMs uint32) error {
+	return AnimateWindow(hwnd, durationMs, AW_BLEND|AW_HIDE)
+}
+
+// ensureLayered adds WS_EX_LAYERED to hwnd. The window is made fully opaque,
+// since a layered window without attributes is not drawn at all.
+func ensureLayered(hwnd windows.HWND) error {
+	style, err := GetWindowLongPtrW(hwnd, GWL_EXSTYLE)
+	if err != nil {
+		return err
+	}
+	exStyle := uint32(style)
+	if exStyle&WS_EX_LAYERED != 0 {
+		return nil
+	}
+	if _, err := SetWindowLongPtrW(hwnd, GWL_EXSTYLE, uintptr(exStyle|WS_EX_LAYERED)); err != nil {
+		return err
+	}
+
+	r1, _, err := User32.NewProc("SetLayeredWindowAttributes").Call(uintptr(hwnd), 0, 255, uintptr(LWA_ALPHA))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
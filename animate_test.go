@@ -0,0 +1,32 @@
+package win32utils
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestAnimateWindow(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// AW_HIDE fails on a window that is already hidden, so start out visible.
+	hwnd, err := CreateWindowExW(0, "STATIC", "", WS_POPUP|WS_VISIBLE, 0, 0, 50, 50, 0, 0, moduleHandle(), 0)
+	if err != nil {
+		t.Fatalf("CreateWindowExW() error = %v", err)
+	}
+	defer DestroyWindow(hwnd)
+
+	if err := AnimateWindow(hwnd, 50, AW_HIDE|AW_BLEND); err != nil {
+		t.Fatalf("AnimateWindow() error = %v", err)
+	}
+	exStyle, err := GetWindowLongPtrW(hwnd, GWL_EXSTYLE)
+	if err != nil {
+		t.Fatalf("GetWindowLongPtrW() error = %v", err)
+	}
+	if uint32(exStyle)&WS_EX_LAYERED == 0 {
+		t.Errorf("extended style = 0x%X, want WS_EX_LAYERED added for AW_BLEND", exStyle)
+	}
+	if style, _ := GetWindowStyle(hwnd); style.Bits&WindowStyleBits(WS_VISIBLE) != 0 {
+		t.Error("window is still visible after AW_HIDE")
+	}
+}
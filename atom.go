@@ -0,0 +1,51 @@
+package win32utils
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// GlobalAddAtom adds s to the global atom table, or increments its reference count if it is already there.
+func GlobalAddAtom(s string) (uint16, error) {
+	ptr, err := windows.UTF16PtrFromString(s)
+	if err != nil {
+		return 0, err
+	}
+	r1, _, err := Kernel32.NewProc("GlobalAddAtomW").Call(uintptr(unsafe.Pointer(ptr)))
+	if r1 == 0 {
+		return 0, err
+	}
+	return uint16(r1), nil
+}
+
+// GlobalFindAtom searches the global atom table for s.
+func GlobalFindAtom(s string) (uint16, bool) {
+	ptr, err := windows.UTF16PtrFromString(s)
+	if err != nil {
+		return 0, false
+	}
+	r1, _, _ := Kernel32.NewProc("GlobalFindAtomW").Call(uintptr(unsafe.Pointer(ptr)))
+	return uint16(r1), r1 != 0
+}
+
+// GlobalDeleteAtom decrements the reference count of a global atom and removes it when the count reaches zero.
+func GlobalDeleteAtom(atom uint16) error {
+	// GlobalDeleteAtom returns zero on success and the atom on failure.
+	r1, _, err := Kernel32.NewProc("GlobalDeleteAtom").Call(uintptr(atom))
+	if r1 != 0 {
+		return err
+	}
+	return nil
+}
+
+// GlobalGetAtomNameW retrieves the string associated with a global atom.
+func GlobalGetAtomNameW(atom uint16) (string, error) {
+	// Atom names are limited to 255 characters.
+	var buf [256]uint16
+	r1, _, err := Kernel32.NewProc("GlobalGetAtomNameW").Call(uintptr(atom), uintptr(unsafe.Pointer(&buf[0])), uintptr(len(buf)))
+	if r1 == 0 {
+		return "", err
+	}
+	return windows.UTF16ToString(buf[:r1]), nil
+}
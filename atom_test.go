@@ -0,0 +1,30 @@
+package win32utils
+
+import "testing"
+
+func TestGlobalAtom(t *testing.T) {
+	const name = "win32utils_test_atom"
+
+	atom, err := GlobalAddAtom(name)
+	if err != nil {
+		t.Fatalf("GlobalAddAtom() error = %v", err)
+	}
+
+	found, ok := GlobalFindAtom(name)
+	if !ok || found != atom {
+		t.Errorf("GlobalFindAtom() = %d, %v, want %d, true", found, ok, atom)
+	}
+	got, err := GlobalGetAtomNameW(atom)
+	if err != nil {
+		t.Errorf("GlobalGetAtomNameW() error = %v", err)
+	} else if got != name {
+		t.Errorf("GlobalGetAtomNameW() = %q, want %q", got, name)
+	}
+
+	if err := GlobalDeleteAtom(atom); err != nil {
+		t.Fatalf("GlobalDeleteAtom() error = %v", err)
+	}
+	if _, ok := GlobalFindAtom(name); ok {
+		t.Error("GlobalFindAtom() found the atom after GlobalDeleteAtom")
+	}
+}
@@ -0,0 +1,63 @@
+package win32utils
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// CreateCaret creates a new shape for the system caret and assigns ownership of the caret to hwnd.
+// If hBitmap is 0 the caret is a solid block of width by height; the caret is hidden until ShowCaret is called.
+func CreateCaret(hwnd windows.HWND, hBitmap windows.Handle, width, height int32) error {
+	r1, _, err := User32.NewProc("CreateCaret").Call(uintptr(hwnd), uintptr(hBitmap), uintptr(width), uintptr(height))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// SetCaretPos moves the caret to the specified client coordinates of the owning window.
+func SetCaretPos(x, y int32) error {
+	r1, _, err := User32.NewProc("SetCaretPos").Call(uintptr(x), uintptr(y))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// GetCaretPos retrieves the caret's position in client coordinates of the owning window.
+func GetCaretPos() (x, y int32, err error) {
+	var pt POINT
+	r1, _, err := User32.NewProc("GetCaretPos").Call(uintptr(unsafe.Pointer(&pt)))
+	if r1 == 0 {
+		return 0, 0, err
+	}
+	return pt.X, pt.Y, nil
+}
+
+// ShowCaret makes the caret visible at its current position.
+func ShowCaret(hwnd windows.HWND) error {
+	r1, _, err := User32.NewProc("ShowCaret").Call(uintptr(hwnd))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// HideCaret removes the caret from the screen without destroying it.
+func HideCaret(hwnd windows.HWND) error {
+	r1, _, err := User32.NewProc("HideCaret").Call(uintptr(hwnd))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// DestroyCaret destroys the caret's current shape and frees it from the window.
+func DestroyCaret() error {
+	r1, _, err := User32.NewProc("DestroyCaret").Call()
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package win32utils
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestCaret(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	hwnd, err := CreateWindowExW(0, "STATIC", "", WS_POPUP, 0, 0, 100, 50, 0, 0, moduleHandle(), 0)
+	if err != nil {
+		t.Fatalf("CreateWindowExW() error = %v", err)
+	}
+	defer DestroyWindow(hwnd)
+
+	if err := CreateCaret(hwnd, 0, 2, 16); err != nil {
+		t.Fatalf("CreateCaret() error = %v", err)
+	}
+	if err := SetCaretPos(10, 20); err != nil {
+		t.Fatalf("SetCaretPos() error = %v", err)
+	}
+	x, y, err := GetCaretPos()
+	if err != nil {
+		t.Fatalf("GetCaretPos() error = %v", err)
+	}
+	if x != 10 || y != 20 {
+		t.Errorf("GetCaretPos() = %d, %d, want 10, 20", x, y)
+	}
+	if err := DestroyCaret(); err != nil {
+		t.Errorf("DestroyCaret() error = %v", err)
+	}
+}
@@ -1,37 +1,214 @@
 package win32utils
 
 import (
-	"fmt"
+	"bytes"
+	"runtime"
 	"testing"
+	"unsafe"
 
 	"golang.org/x/sys/windows"
 )
 
-func TestMain(m *testing.M) {
-	err := OpenClipboard(windows.HWND(GetConsoleWindows()))
-	if err != nil {
-		panic(err)
-	}
+func TestClipboardRoundTrip(t *testing.T) {
+	const text = "你好 Win32\r\nこんにちは Win32"
 
+	if err := OpenClipboard(windows.HWND(GetConsoleWindows())); err != nil {
+		t.Fatalf("OpenClipboard() error = %v", err)
+	}
 	defer func() {
-		err = CloseClipboard()
-		if err != nil {
-			panic(err)
+		if err := CloseClipboard(); err != nil {
+			t.Errorf("CloseClipboard() error = %v", err)
 		}
 	}()
 
-	err = EmptyClipboard()
+	if err := EmptyClipboard(); err != nil {
+		t.Fatalf("EmptyClipboard() error = %v", err)
+	}
+	if _, err := SetClipboardText(text); err != nil {
+		t.Fatalf("SetClipboardText() error = %v", err)
+	}
+	got, err := GetClipboardDataText()
+	if err != nil {
+		t.Fatalf("GetClipboardDataText() error = %v", err)
+	}
+	if got != text {
+		t.Errorf("GetClipboardDataText() = %q, want %q", got, text)
+	}
+}
+
+func TestGetClipboardDataRaw(t *testing.T) {
+	const text = "你好 Win32 raw"
+	if err := SetText(text); err != nil {
+		t.Fatalf("SetText() error = %v", err)
+	}
+
+	data, err := GetClipboardDataRaw(uint32(CF_UNICODETEXT))
+	if err != nil {
+		t.Fatalf("GetClipboardDataRaw() error = %v", err)
+	}
+	u16 := unsafe.Slice((*uint16)(unsafe.Pointer(&data[0])), len(data)/2)
+	if got := windows.UTF16ToString(u16); got != text {
+		t.Errorf("GetClipboardDataRaw() = %q, want %q", got, text)
+	}
+}
+
+func TestGetClipboardDataRawOpenClipboard(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	const text = "win32utils raw in session"
+	if err := SetText(text); err != nil {
+		t.Fatalf("SetText() error = %v", err)
+	}
+
+	if err := OpenClipboard(0); err != nil {
+		t.Fatalf("OpenClipboard() error = %v", err)
+	}
+	defer CloseClipboard()
+
+	if _, err := GetClipboardDataRaw(uint32(CF_UNICODETEXT)); err != nil {
+		t.Fatalf("GetClipboardDataRaw() error = %v", err)
+	}
+	// The caller's session must survive: GetClipboardDataText needs the clipboard open.
+	if got, err := GetClipboardDataText(); err != nil || got != text {
+		t.Errorf("GetClipboardDataText() after GetClipboardDataRaw = %q, %v, want %q", got, err, text)
+	}
+}
+
+func TestSetClipboardDataRaw(t *testing.T) {
+	format, err := RegisterClipboardFormatW("win32utils test format")
+	if err != nil {
+		t.Fatalf("RegisterClipboardFormatW() error = %v", err)
+	}
+
+	want := []byte{0x00, 0x01, 0x7F, 0x80, 0xFF}
+	if err := SetClipboardDataRaw(format, want, true); err != nil {
+		t.Fatalf("SetClipboardDataRaw() error = %v", err)
+	}
+
+	got, err := GetClipboardDataRaw(format)
+	if err != nil {
+		t.Fatalf("GetClipboardDataRaw() error = %v", err)
+	}
+	// GlobalSize may report a block larger than requested.
+	if !bytes.HasPrefix(got, want) {
+		t.Errorf("GetClipboardDataRaw() = %v, want prefix %v", got, want)
+	}
+}
+
+func TestGetOpenClipboardWindow(t *testing.T) {
+	if err := WaitForClipboard(0, 1000); err != nil {
+		t.Skipf("clipboard is busy: %v", err)
+	}
+	owner, err := GetOpenClipboardWindow()
+	if err != nil {
+		t.Fatalf("GetOpenClipboardWindow() error = %v", err)
+	}
+	if owner != 0 {
+		t.Errorf("GetOpenClipboardWindow() = 0x%X, want 0", owner)
+	}
+}
+
+func TestClipboardHTML(t *testing.T) {
+	const fragment = "<b>你好</b> HTML"
+	if err := SetClipboardHTML(fragment, ""); err != nil {
+		t.Fatalf("SetClipboardHTML() error = %v", err)
+	}
+	got, err := GetClipboardHTML()
 	if err != nil {
-		panic(err)
+		t.Fatalf("GetClipboardHTML() error = %v", err)
+	}
+	if got != fragment {
+		t.Errorf("GetClipboardHTML() = %q, want %q", got, fragment)
 	}
+}
 
-	h, err := SetClipboardText("你好 Win32\r\nこんにちは Win32")
-	fmt.Printf("err: %v\n", err)
+func TestBuildCFHTML(t *testing.T) {
+	doc := "<html><body><p>before</p><i>x</i></body></html>"
+	data, err := buildCFHTML("<i>x</i>", doc)
+	if err != nil {
+		t.Fatalf("buildCFHTML() error = %v", err)
+	}
+	got, err := parseCFHTML(data)
 	if err != nil {
-		panic(err)
+		t.Fatalf("parseCFHTML() error = %v", err)
+	}
+	if got != "<i>x</i>" {
+		t.Errorf("parseCFHTML() = %q, want %q", got, "<i>x</i>")
+	}
+	start, _ := cfHTMLOffset(data, "StartHTML:")
+	if !bytes.HasPrefix(data[start:], []byte("<html>")) {
+		t.Errorf("StartHTML = %d does not point at the document", start)
+	}
+}
+
+func TestClipboardImage(t *testing.T) {
+	pixels := []byte{
+		0xFF, 0x00, 0x00, 0xFF, 0x00, 0xFF, 0x00, 0xFF, 0x00, 0x00, 0xFF, 0xFF,
+		0x10, 0x20, 0x30, 0xFF, 0x40, 0x50, 0x60, 0xFF, 0x70, 0x80, 0x90, 0xFF,
+	}
+	if err := SetClipboardImage(3, 2, pixels); err != nil {
+		t.Fatalf("SetClipboardImage() error = %v", err)
+	}
+	width, height, got, err := GetClipboardImage()
+	if err != nil {
+		t.Fatalf("GetClipboardImage() error = %v", err)
+	}
+	if width != 3 || height != 2 || !bytes.Equal(got, pixels) {
+		t.Errorf("GetClipboardImage() = %dx%d %v, want 3x2 %v", width, height, got, pixels)
+	}
+}
+
+func TestClipboardImageOpenClipboard(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	if err := OpenClipboard(0); err != nil {
+		t.Fatalf("OpenClipboard() error = %v", err)
+	}
+	defer CloseClipboard()
+	if err := EmptyClipboard(); err != nil {
+		t.Fatalf("EmptyClipboard() error = %v", err)
+	}
+
+	const text = "win32utils image in session"
+	if _, err := SetClipboardText(text); err != nil {
+		t.Fatalf("SetClipboardText() error = %v", err)
+	}
+	pixels := []byte{0x01, 0x02, 0x03, 0xFF}
+	if err := SetClipboardImage(1, 1, pixels); err != nil {
+		t.Fatalf("SetClipboardImage() error = %v", err)
+	}
+	if _, _, got, err := GetClipboardImage(); err != nil || !bytes.Equal(got, pixels) {
+		t.Errorf("GetClipboardImage() = %v, %v, want %v", got, err, pixels)
+	}
+	// Neither call may have emptied or closed the caller's session.
+	if got, err := GetClipboardDataText(); err != nil || got != text {
+		t.Errorf("GetClipboardDataText() = %q, %v, want %q", got, err, text)
+	}
+}
+
+func TestParseDIB24(t *testing.T) {
+	// A bottom-up 1x2 24-bit bitmap, whose rows are padded to 4 bytes.
+	data := make([]byte, 40+2*4)
+	data[0] = 40
+	data[4] = 1
+	data[8] = 2
+	data[12] = 1
+	data[14] = 24
+	copy(data[40:], []byte{0x03, 0x02, 0x01, 0, 0x06, 0x05, 0x04, 0})
+
+	width, height, pixels, err := parseDIB(data)
+	if err != nil {
+		t.Fatalf("parseDIB() error = %v", err)
+	}
+	want := []byte{0x04, 0x05, 0x06, 0xFF, 0x01, 0x02, 0x03, 0xFF}
+	if width != 1 || height != 2 || !bytes.Equal(pixels, want) {
+		t.Errorf("parseDIB() = %dx%d %v, want 1x2 %v", width, height, pixels, want)
+	}
+
+	data[14] = 8
+	if _, _, _, err := parseDIB(data); err != errUnsupportedDIB {
+		t.Errorf("parseDIB() of an 8-bit bitmap error = %v, want %v", err, errUnsupportedDIB)
 	}
-	datab, err := GetClipboardDataText()
-	fmt.Printf("err: %v\n", err)
-	fmt.Printf("datab: %v\n", datab)
-	fmt.Printf("h: %v\n", h)
 }
@@ -1,33 +1,40 @@
 package win32utils
 
 import (
+	"runtime"
+	"strconv"
+	"time"
 	"unsafe"
 
 	"golang.org/x/sys/windows"
 )
 
 const CF_TEXT uintptr = 1
+const CF_DIB uintptr = 8
 const CF_UNICODETEXT uintptr = 13
 const CF_LOCALE uintptr = 16
 
+const clipboardOpenAttempts = 10
+const clipboardRetryDelay = 10 * time.Millisecond
+
 func OpenClipboard(hwnd windows.HWND) error {
-	r1, _, _ := User32.NewProc("OpenClipboard").Call(uintptr(hwnd))
+	r1, _, err := User32.NewProc("OpenClipboard").Call(uintptr(hwnd))
 	if r1 == 0 {
-		return windows.GetLastError()
+		return err
 	}
 	return nil
 }
 func CloseClipboard() error {
-	r1, _, _ := User32.NewProc("CloseClipboard").Call()
+	r1, _, err := User32.NewProc("CloseClipboard").Call()
 	if r1 == 0 {
-		return windows.GetLastError()
+		return err
 	}
 	return nil
 }
 func EmptyClipboard() error {
-	r1, _, _ := User32.NewProc("EmptyClipboard").Call()
+	r1, _, err := User32.NewProc("EmptyClipboard").Call()
 	if r1 == 0 {
-		return windows.GetLastError()
+		return err
 	}
 	return nil
 }
@@ -57,18 +64,18 @@ func SetClipboardText(text string) (handle windows.Handle, err error) {
 		return 0, err
 	}
 
-	r1, _, _ := proc.Call(CF_UNICODETEXT, uintptr(h))
+	r1, _, err := proc.Call(CF_UNICODETEXT, uintptr(h))
 	if r1 == 0 {
-		return 0, windows.GetLastError()
+		return 0, err
 	}
 
 	return windows.Handle(r1), nil
 }
 
 func GetClipboardDataText() (string, error) {
-	r1, _, _ := User32.NewProc("GetClipboardData").Call(CF_UNICODETEXT)
+	r1, _, err := User32.NewProc("GetClipboardData").Call(CF_UNICODETEXT)
 	if r1 == 0 {
-		return "", windows.GetLastError()
+		return "", err
 	}
 
 	p, err := GlobalLock(windows.Handle(r1))
@@ -80,11 +87,186 @@ func GetClipboardDataText() (string, error) {
 	return windows.UTF16PtrToString((*uint16)(unsafe.Pointer(p))), nil
 }
 
+// clipboardOpenByCaller reports whether the calling thread has the clipboard open.
+// EnumClipboardFormats fails with ERROR_CLIPBOARD_NOT_OPEN otherwise, even if the clipboard
+// was opened without a window.
+func clipboardOpenByCaller() bool {
+	r1, _, err := User32.NewProc("EnumClipboardFormats").Call(0)
+	return r1 != 0 || err != windows.ERROR_CLIPBOARD_NOT_OPEN
+}
+
+// GetClipboardDataRaw returns a copy of the clipboard data in the given format.
+// Unless the calling thread already has the clipboard open, it is opened and closed internally,
+// retrying while another window holds it open.
+func GetClipboardDataRaw(format uint32) ([]byte, error) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	if !clipboardOpenByCaller() {
+		err := openClipboardRetry(0)
+		if err != nil {
+			return nil, err
+		}
+		defer CloseClipboard()
+	}
+
+	r1, _, err := User32.NewProc("GetClipboardData").Call(uintptr(format))
+	if r1 == 0 {
+		return nil, err
+	}
+	h := windows.Handle(r1)
+
+	size, err := GlobalSize(h)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := GlobalLock(h)
+	if err != nil {
+		return nil, err
+	}
+	defer GlobalUnlock(h)
+
+	data := make([]byte, size)
+	copy(data, unsafe.Slice((*byte)(unsafe.Pointer(p)), size))
+	return data, nil
+}
+
+// SetClipboardDataRaw places data on the clipboard in the given format.
+// If own is true the clipboard is opened and emptied first and closed afterwards;
+// otherwise the caller must already have opened and emptied it.
+func SetClipboardDataRaw(format uint32, data []byte, own bool) error {
+	if own {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+
+		err := openClipboardRetry(0)
+		if err != nil {
+			return err
+		}
+		defer CloseClipboard()
+
+		err = EmptyClipboard()
+		if err != nil {
+			return err
+		}
+	}
+
+	h, err := GlobalAlloc(uint(GMEM_MOVEABLE), uint(len(data)))
+	if err != nil {
+		return err
+	}
+
+	p, err := GlobalLock(h)
+	if err != nil {
+		GlobalFree(h)
+		return err
+	}
+	copy(unsafe.Slice((*byte)(unsafe.Pointer(p)), len(data)), data)
+
+	err = GlobalUnlock(h)
+	if err != nil {
+		GlobalFree(h)
+		return err
+	}
+
+	// The system owns the memory once SetClipboardData succeeds.
+	r1, _, err := User32.NewProc("SetClipboardData").Call(uintptr(format), uintptr(h))
+	if r1 == 0 {
+		GlobalFree(h)
+		return err
+	}
+	return nil
+}
+
+// RegisterClipboardFormatW registers a new clipboard format, or returns the existing one with the same name.
+func RegisterClipboardFormatW(name string) (uint32, error) {
+	namePtr, err := windows.UTF16PtrFromString(name)
+	if err != nil {
+		return 0, err
+	}
+	r1, _, err := User32.NewProc("RegisterClipboardFormatW").Call(uintptr(unsafe.Pointer(namePtr)))
+	if r1 == 0 {
+		return 0, err
+	}
+	return uint32(r1), nil
+}
+
+// ClipboardBusyError is returned when the clipboard stays open by another window.
+type ClipboardBusyError struct {
+	// Owner is the window that had the clipboard open, or 0 if it was released in the meantime.
+	Owner windows.HWND
+	Err   error
+}
+
+func (e *ClipboardBusyError) Error() string {
+	return "win32utils: clipboard is held open by window 0x" + strconv.FormatUint(uint64(e.Owner), 16) + ": " + e.Err.Error()
+}
+
+func (e *ClipboardBusyError) Unwrap() error {
+	return e.Err
+}
+
+// GetOpenClipboardWindow retrieves the window that currently has the clipboard open, or 0 if none does.
+func GetOpenClipboardWindow() (windows.HWND, error) {
+	r1, _, err := User32.NewProc("GetOpenClipboardWindow").Call()
+	if r1 == 0 && err != windows.ERROR_SUCCESS {
+		return 0, err
+	}
+	return windows.HWND(r1), nil
+}
+
+// WaitForClipboard waits up to timeoutMs milliseconds until no window has the clipboard open,
+// dispatching the messages of hwnd, if it is not 0, so that it can still render delayed clipboard formats.
+// IsClipboardFormatAvailable cannot be used for this, because it succeeds while another window has the clipboard open.
+// It returns windows.ERROR_TIMEOUT if the clipboard is still open when the timeout expires.
+func WaitForClipboard(hwnd windows.HWND, timeoutMs uint32) error {
+	deadline := time.Now().Add(time.Duration(timeoutMs) * time.Millisecond)
+	var msg MSG
+	for {
+		owner, err := GetOpenClipboardWindow()
+		if err != nil {
+			return err
+		}
+		if owner == 0 {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return windows.ERROR_TIMEOUT
+		}
+
+		for hwnd != 0 && PeekMessageW(&msg, hwnd, 0, 0, PM_REMOVE) {
+			TranslateMessage(&msg)
+			DispatchMessageW(&msg)
+		}
+		time.Sleep(clipboardRetryDelay)
+	}
+}
+
+// openClipboardRetry opens the clipboard, retrying for a short while if another window has it open.
+// If it never succeeds, the error is a *ClipboardBusyError naming the window that held the clipboard.
+func openClipboardRetry(hwnd windows.HWND) (err error) {
+	for i := 0; i < clipboardOpenAttempts; i++ {
+		err = OpenClipboard(hwnd)
+		if err == nil {
+			return nil
+		}
+		time.Sleep(clipboardRetryDelay)
+	}
+	owner, _ := GetOpenClipboardWindow()
+	return &ClipboardBusyError{Owner: owner, Err: err}
+}
+
+// SetText replaces the clipboard contents with text.
 func SetText(text string) error {
-	err := OpenClipboard(windows.GetShellWindow())
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	err := openClipboardRetry(windows.GetShellWindow())
 	if err != nil {
 		return err
 	}
+	defer CloseClipboard()
 
 	err = EmptyClipboard()
 	if err != nil {
@@ -96,10 +278,19 @@ func SetText(text string) error {
 		return err
 	}
 
-	err = CloseClipboard()
+	return nil
+}
+
+// GetText returns the text on the clipboard.
+func GetText() (string, error) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	err := openClipboardRetry(0)
 	if err != nil {
-		return err
+		return "", err
 	}
+	defer CloseClipboard()
 
-	return nil
+	return GetClipboardDataText()
 }
@@ -0,0 +1,121 @@
+package win32utils
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// CF_HTML_NAME is the name of the registered clipboard format for HTML.
+const CF_HTML_NAME = "HTML Format"
+
+const (
+	htmlStartFragment = "<!--StartFragment-->"
+	htmlEndFragment   = "<!--EndFragment-->"
+)
+
+var errInvalidCFHTML = errors.New("win32utils: invalid HTML Format clipboard data")
+
+// GetClipboardHTML returns the HTML fragment on the clipboard, without the CF_HTML header
+// and the surrounding document.
+func GetClipboardHTML() (string, error) {
+	format, err := RegisterClipboardFormatW(CF_HTML_NAME)
+	if err != nil {
+		return "", err
+	}
+	data, err := GetClipboardDataRaw(format)
+	if err != nil {
+		return "", err
+	}
+	return parseCFHTML(data)
+}
+
+// SetClipboardHTML places HTML on the clipboard in the CF_HTML format.
+// fullDoc is the complete document that contains fragment, ideally between <!--StartFragment-->
+// and <!--EndFragment--> comments. If fullDoc is empty, a minimal document is built around fragment.
+func SetClipboardHTML(fragment, fullDoc string) error {
+	format, err := RegisterClipboardFormatW(CF_HTML_NAME)
+	if err != nil {
+		return err
+	}
+	data, err := buildCFHTML(fragment, fullDoc)
+	if err != nil {
+		return err
+	}
+	return SetClipboardDataRaw(format, data, true)
+}
+
+func buildCFHTML(fragment, fullDoc string) ([]byte, error) {
+	if fullDoc == "" {
+		fullDoc = "<html><body>" + htmlStartFragment + fragment + htmlEndFragment + "</body></html>"
+	}
+
+	var start, end int
+	if i := strings.Index(fullDoc, htmlStartFragment); i >= 0 {
+		start = i + len(htmlStartFragment)
+		j := strings.Index(fullDoc[start:], htmlEndFragment)
+		if j < 0 {
+			return nil, errors.New("win32utils: " + htmlStartFragment + " without " + htmlEndFragment)
+		}
+		end = start + j
+	} else {
+		i := strings.Index(fullDoc, fragment)
+		if i < 0 {
+			return nil, errors.New("win32utils: HTML fragment not found in document")
+		}
+		start, end = i, i+len(fragment)
+	}
+
+	// Offsets are byte offsets from the start of the header, which has a fixed length.
+	headerLen := len(formatCFHTMLHeader(0, 0, 0, 0))
+	header := formatCFHTMLHeader(headerLen, headerLen+len(fullDoc), headerLen+start, headerLen+end)
+	data := make([]byte, 0, len(header)+len(fullDoc)+1)
+	data = append(data, header...)
+	data = append(data, fullDoc...)
+	return append(data, 0), nil
+}
+
+// formatCFHTMLHeader pads the offsets to a fixed width so that the header length does not depend on their values.
+func formatCFHTMLHeader(startHTML, endHTML, startFragment, endFragment int) string {
+	return "Version:0.9\r\n" +
+		"StartHTML:" + padOffset(startHTML) + "\r\n" +
+		"EndHTML:" + padOffset(endHTML) + "\r\n" +
+		"StartFragment:" + padOffset(startFragment) + "\r\n" +
+		"EndFragment:" + padOffset(endFragment) + "\r\n"
+}
+
+func padOffset(n int) string {
+	s := strconv.Itoa(n)
+	return strings.Repeat("0", 10-len(s)) + s
+}
+
+func parseCFHTML(data []byte) (string, error) {
+	start, ok1 := cfHTMLOffset(data, "StartFragment:")
+	end, ok2 := cfHTMLOffset(data, "EndFragment:")
+	if !ok1 || !ok2 || start < 0 || end < start || end > len(data) {
+		return "", errInvalidCFHTML
+	}
+	return string(data[start:end]), nil
+}
+
+// cfHTMLOffset returns the value of a header field such as "StartFragment:".
+func cfHTMLOffset(data []byte, key string) (int, bool) {
+	// The header ends where the HTML begins.
+	header := string(data)
+	if i := strings.IndexByte(header, '<'); i >= 0 {
+		header = header[:i]
+	}
+	i := strings.Index(header, key)
+	if i < 0 {
+		return 0, false
+	}
+	value := header[i+len(key):]
+	if j := strings.IndexAny(value, "\r\n"); j >= 0 {
+		value = value[:j]
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
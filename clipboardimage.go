@@ -0,0 +1,127 @@
+package win32utils
+
+import (
+	"encoding/binary"
+	"errors"
+	"runtime"
+	"unsafe"
+)
+
+var errUnsupportedDIB = errors.New("win32utils: unsupported CF_DIB format")
+
+// GetClipboardImage returns the CF_DIB bitmap on the clipboard as top-down RGBA pixels.
+// Uncompressed 24- and 32-bit bitmaps are supported. The clipboard is opened internally
+// unless the calling thread already has it open.
+func GetClipboardImage() (width, height int, pixels []byte, err error) {
+	data, err := GetClipboardDataRaw(uint32(CF_DIB))
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	return parseDIB(data)
+}
+
+// SetClipboardImage replaces the clipboard contents with a CF_DIB bitmap built from
+// width*height top-down RGBA pixels. If the calling thread already has the clipboard open,
+// the bitmap is added to it without emptying or closing it.
+func SetClipboardImage(width, height int, pixels []byte) error {
+	data, err := buildDIB(width, height, pixels)
+	if err != nil {
+		return err
+	}
+
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	return SetClipboardDataRaw(uint32(CF_DIB), data, !clipboardOpenByCaller())
+}
+
+func parseDIB(data []byte) (width, height int, pixels []byte, err error) {
+	var bih BITMAPINFOHEADER
+	headerSize := int(unsafe.Sizeof(bih))
+	if len(data) < headerSize {
+		return 0, 0, nil, errUnsupportedDIB
+	}
+	bih.BiSize = binary.LittleEndian.Uint32(data[0:])
+	bih.BiWidth = int32(binary.LittleEndian.Uint32(data[4:]))
+	bih.BiHeight = int32(binary.LittleEndian.Uint32(data[8:]))
+	bih.BiBitCount = binary.LittleEndian.Uint16(data[14:])
+	bih.BiCompression = binary.LittleEndian.Uint32(data[16:])
+
+	offset := int(bih.BiSize)
+	switch {
+	case bih.BiCompression == BI_BITFIELDS && bih.BiBitCount == 32:
+		// Only the standard BGRA layout is accepted, so the masks are not interpreted.
+		if bih.BiSize == uint32(headerSize) {
+			offset += 3 * 4
+		}
+	case bih.BiCompression == BI_RGB && (bih.BiBitCount == 24 || bih.BiBitCount == 32):
+	default:
+		return 0, 0, nil, errUnsupportedDIB
+	}
+
+	bottomUp := bih.BiHeight > 0
+	width, height = int(bih.BiWidth), int(bih.BiHeight)
+	if !bottomUp {
+		height = -height
+	}
+	bytesPerPixel := int(bih.BiBitCount) / 8
+	// DIB rows are padded to a multiple of 4 bytes.
+	stride := (width*bytesPerPixel + 3) &^ 3
+	if width <= 0 || height <= 0 || offset < headerSize || len(data) < offset+stride*height {
+		return 0, 0, nil, errUnsupportedDIB
+	}
+
+	pixels = make([]byte, width*height*4)
+	hasAlpha := false
+	for y := 0; y < height; y++ {
+		srcY := y
+		if bottomUp {
+			srcY = height - 1 - y
+		}
+		row := data[offset+srcY*stride:]
+		for x := 0; x < width; x++ {
+			src := row[x*bytesPerPixel:]
+			dst := pixels[(y*width+x)*4:]
+			dst[0], dst[1], dst[2], dst[3] = src[2], src[1], src[0], 0xFF
+			if bytesPerPixel == 4 {
+				dst[3] = src[3]
+				hasAlpha = hasAlpha || src[3] != 0
+			}
+		}
+	}
+	// Most applications leave the fourth byte of 32-bit bitmaps zero; treat them as opaque.
+	if bytesPerPixel == 4 && !hasAlpha {
+		for i := 3; i < len(pixels); i += 4 {
+			pixels[i] = 0xFF
+		}
+	}
+	return width, height, pixels, nil
+}
+
+func buildDIB(width, height int, pixels []byte) ([]byte, error) {
+	if width <= 0 || height <= 0 || len(pixels) != width*height*4 {
+		return nil, errors.New("win32utils: pixels must hold width*height RGBA values")
+	}
+
+	// Bottom-up, since many applications do not handle top-down CF_DIB data.
+	bih := BITMAPINFOHEADER{
+		BiWidth:       int32(width),
+		BiHeight:      int32(height),
+		BiPlanes:      1,
+		BiBitCount:    32,
+		BiCompression: BI_RGB,
+		BiSizeImage:   uint32(len(pixels)),
+	}
+	bih.BiSize = uint32(unsafe.Sizeof(bih))
+
+	data := make([]byte, int(bih.BiSize)+len(pixels))
+	copy(data, unsafe.Slice((*byte)(unsafe.Pointer(&bih)), bih.BiSize))
+	bits := data[bih.BiSize:]
+	for y := 0; y < height; y++ {
+		src := pixels[y*width*4 : (y+1)*width*4]
+		dst := bits[(height-1-y)*width*4:]
+		for x := 0; x < width; x++ {
+			dst[x*4], dst[x*4+1], dst[x*4+2], dst[x*4+3] = src[x*4+2], src[x*4+1], src[x*4], src[x*4+3]
+		}
+	}
+	return data, nil
+}
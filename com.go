@@ -0,0 +1,59 @@
+package win32utils
+
+import (
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const CLSCTX_INPROC_SERVER uint32 = 0x1
+
+// Offsets of the IUnknown methods in every COM vtable.
+const (
+	iunknownQueryInterface = 0
+	iunknownAddRef         = 1
+	iunknownRelease        = 2
+)
+
+// comObject is a pointer to a COM interface, whose first field is the vtable pointer.
+type comObject struct {
+	vtbl *[64]uintptr
+}
+
+// coCreateInstance creates a single object of the class clsid and returns its iid interface.
+// COM must have been initialized on the calling thread, e.g. with windows.CoInitializeEx.
+func coCreateInstance(clsid *windows.GUID, iid *windows.GUID) (*comObject, error) {
+	var obj *comObject
+	r1, _, _ := Ole32.NewProc("CoCreateInstance").Call(
+		uintptr(unsafe.Pointer(clsid)),
+		0,
+		uintptr(CLSCTX_INPROC_SERVER),
+		uintptr(unsafe.Pointer(iid)),
+		uintptr(unsafe.Pointer(&obj)))
+	if err := hresultError(r1); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// method returns the address of the vtable method at index.
+// Callers pass it to syscall.SyscallN directly, with the object itself as the first argument,
+// so that pointer arguments stay alive for the duration of the call.
+func (o *comObject) method(index int) uintptr {
+	return o.vtbl[index]
+}
+
+// Release decrements the reference count of the object.
+func (o *comObject) Release() uint32 {
+	r1, _, _ := syscall.SyscallN(o.method(iunknownRelease), uintptr(unsafe.Pointer(o)))
+	return uint32(r1)
+}
+
+// hresultError converts a failed HRESULT into an error.
+func hresultError(hr uintptr) error {
+	if int32(hr) < 0 {
+		return windows.Errno(hr)
+	}
+	return nil
+}
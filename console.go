@@ -0,0 +1,357 @@
+package win32utils
+
+import (
+	"os"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// GetConsoleProcessList retrieves the IDs of all processes attached to the current console.
+func GetConsoleProcessList() ([]uint32, error) {
+	pids := make([]uint32, 4)
+	for {
+		r1, _, err := Kernel32.NewProc("GetConsoleProcessList").Call(uintptr(unsafe.Pointer(&pids[0])), uintptr(len(pids)))
+		if r1 == 0 {
+			return nil, err
+		}
+		// A return value larger than the buffer is the number of elements needed.
+		if int(r1) <= len(pids) {
+			return pids[:r1], nil
+		}
+		pids = make([]uint32, r1)
+	}
+}
+
+// CP_UTF8 is the UTF-8 code page identifier.
+const CP_UTF8 uint32 = 65001
+
+// Console input modes for GetConsoleMode and SetConsoleMode.
+const (
+	ENABLE_PROCESSED_INPUT        uint32 = 0x0001
+	ENABLE_LINE_INPUT             uint32 = 0x0002
+	ENABLE_ECHO_INPUT             uint32 = 0x0004
+	ENABLE_WINDOW_INPUT           uint32 = 0x0008
+	ENABLE_MOUSE_INPUT            uint32 = 0x0010
+	ENABLE_INSERT_MODE            uint32 = 0x0020
+	ENABLE_QUICK_EDIT_MODE        uint32 = 0x0040
+	ENABLE_EXTENDED_FLAGS         uint32 = 0x0080
+	ENABLE_AUTO_POSITION          uint32 = 0x0100
+	ENABLE_VIRTUAL_TERMINAL_INPUT uint32 = 0x0200
+)
+
+// Console output modes for GetConsoleMode and SetConsoleMode.
+// ENABLE_VIRTUAL_TERMINAL_PROCESSING makes the console interpret VT100 escape sequences.
+const (
+	ENABLE_PROCESSED_OUTPUT            uint32 = 0x0001
+	ENABLE_WRAP_AT_EOL_OUTPUT          uint32 = 0x0002
+	ENABLE_VIRTUAL_TERMINAL_PROCESSING uint32 = 0x0004
+	DISABLE_NEWLINE_AUTO_RETURN        uint32 = 0x0008
+	ENABLE_LVB_GRID_WORLDWIDE          uint32 = 0x0010
+)
+
+// GetConsoleMode retrieves the input mode of a console input handle or the output mode of a screen buffer handle.
+func GetConsoleMode(handle windows.Handle) (uint32, error) {
+	var mode uint32
+	r1, _, err := Kernel32.NewProc("GetConsoleMode").Call(uintptr(handle), uintptr(unsafe.Pointer(&mode)))
+	if r1 == 0 {
+		return 0, err
+	}
+	return mode, nil
+}
+
+// SetConsoleMode sets the input mode of a console input handle or the output mode of a screen buffer handle.
+func SetConsoleMode(handle windows.Handle, mode uint32) error {
+	r1, _, err := Kernel32.NewProc("SetConsoleMode").Call(uintptr(handle), uintptr(mode))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// EnableVirtualTerminalProcessing makes the console interpret ANSI escape sequences written to
+// standard output (Windows 10+). It fails if standard output is not a console.
+func EnableVirtualTerminalProcessing() error {
+	stdout, err := windows.GetStdHandle(windows.STD_OUTPUT_HANDLE)
+	if err != nil {
+		return err
+	}
+	mode, err := GetConsoleMode(stdout)
+	if err != nil {
+		return err
+	}
+	return SetConsoleMode(stdout, mode|ENABLE_VIRTUAL_TERMINAL_PROCESSING|DISABLE_NEWLINE_AUTO_RETURN)
+}
+
+// GetConsoleOutputCP retrieves the output code page used by the console.
+func GetConsoleOutputCP() (uint32, error) {
+	r1, _, err := Kernel32.NewProc("GetConsoleOutputCP").Call()
+	if r1 == 0 {
+		return 0, err
+	}
+	return uint32(r1), nil
+}
+
+// SetConsoleOutputCP sets the output code page used by the console.
+func SetConsoleOutputCP(cp uint32) error {
+	r1, _, err := Kernel32.NewProc("SetConsoleOutputCP").Call(uintptr(cp))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// GetConsoleInputCP retrieves the input code page used by the console.
+func GetConsoleInputCP() (uint32, error) {
+	r1, _, err := Kernel32.NewProc("GetConsoleCP").Call()
+	if r1 == 0 {
+		return 0, err
+	}
+	return uint32(r1), nil
+}
+
+// SetConsoleInputCP sets the input code page used by the console.
+func SetConsoleInputCP(cp uint32) error {
+	r1, _, err := Kernel32.NewProc("SetConsoleCP").Call(uintptr(cp))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// ATTACH_PARENT_PROCESS makes AttachConsole attach to the console of the parent process.
+const ATTACH_PARENT_PROCESS uint32 = 0xFFFFFFFF
+
+// AllocConsole creates a new console for the calling process, which must not have one.
+// Call ReopenStdHandles afterwards so that os.Stdout and friends write to it.
+func AllocConsole() error {
+	r1, _, err := Kernel32.NewProc("AllocConsole").Call()
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// FreeConsole detaches the calling process from its console.
+func FreeConsole() error {
+	r1, _, err := Kernel32.NewProc("FreeConsole").Call()
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// AttachConsole attaches the calling process to the console of processID, or of the parent
+// process with ATTACH_PARENT_PROCESS. It fails with ERROR_ACCESS_DENIED if the process already
+// has a console. A GUI program started from a terminal can print to it with:
+//
+//	if err := AttachConsole(ATTACH_PARENT_PROCESS); err == nil {
+//		ReopenStdHandles()
+//		defer FreeConsole()
+//	}
+//	fmt.Println("hello")
+func AttachConsole(processID uint32) error {
+	r1, _, err := Kernel32.NewProc("AttachConsole").Call(uintptr(processID))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// ReopenStdHandles replaces os.Stdin, os.Stdout and os.Stderr with the current standard handles,
+// which AllocConsole and AttachConsole change without updating the os package. Standard handles
+// that were never set, as in GUI programs, are pointed at the console's CONIN$ and CONOUT$ first;
+// handles that were redirected by the parent are kept.
+func ReopenStdHandles() error {
+	std := []struct {
+		id      uint32
+		console string
+		file    **os.File
+		name    string
+	}{
+		{windows.STD_INPUT_HANDLE, "CONIN$", &os.Stdin, "/dev/stdin"},
+		{windows.STD_OUTPUT_HANDLE, "CONOUT$", &os.Stdout, "/dev/stdout"},
+		{windows.STD_ERROR_HANDLE, "CONOUT$", &os.Stderr, "/dev/stderr"},
+	}
+	for _, s := range std {
+		h, err := windows.GetStdHandle(s.id)
+		if err != nil || h == 0 {
+			h, err = CreateFileW(s.console, GENERIC_READ|GENERIC_WRITE, FILE_SHARE_READ|FILE_SHARE_WRITE, OPEN_EXISTING, 0)
+			if err != nil {
+				return err
+			}
+			if err := windows.SetStdHandle(s.id, h); err != nil {
+				CloseHandle(h)
+				return err
+			}
+		}
+		// Replacing a file that wraps the same handle would let its finalizer close the handle.
+		if *s.file != nil && (*s.file).Fd() == uintptr(h) {
+			continue
+		}
+		*s.file = os.NewFile(uintptr(h), s.name)
+	}
+	return nil
+}
+
+// EnableUTF8Console switches the console input and output code pages to UTF-8
+// and enables virtual terminal processing on standard output.
+func EnableUTF8Console() error {
+	err := SetConsoleInputCP(CP_UTF8)
+	if err != nil {
+		return err
+	}
+	err = SetConsoleOutputCP(CP_UTF8)
+	if err != nil {
+		return err
+	}
+
+	stdout, err := windows.GetStdHandle(windows.STD_OUTPUT_HANDLE)
+	if err != nil {
+		return err
+	}
+	mode, err := GetConsoleMode(stdout)
+	if err != nil {
+		return err
+	}
+	return SetConsoleMode(stdout, mode|ENABLE_VIRTUAL_TERMINAL_PROCESSING)
+}
+
+// COORD defines the coordinates of a character cell in a console screen buffer.
+type COORD struct {
+	X int16
+	Y int16
+}
+
+// SMALL_RECT defines the coordinates of the corners of a rectangle of console character cells.
+type SMALL_RECT struct {
+	Left   int16
+	Top    int16
+	Right  int16
+	Bottom int16
+}
+
+// CONSOLE_SCREEN_BUFFER_INFO contains information about a console screen buffer.
+type CONSOLE_SCREEN_BUFFER_INFO struct {
+	Size              COORD
+	CursorPosition    COORD
+	Attributes        uint16
+	Window            SMALL_RECT
+	MaximumWindowSize COORD
+}
+
+// GetConsoleScreenBufferInfo retrieves information about the specified console screen buffer.
+func GetConsoleScreenBufferInfo(handle windows.Handle) (CONSOLE_SCREEN_BUFFER_INFO, error) {
+	var info CONSOLE_SCREEN_BUFFER_INFO
+	r1, _, err := Kernel32.NewProc("GetConsoleScreenBufferInfo").Call(uintptr(handle), uintptr(unsafe.Pointer(&info)))
+	if r1 == 0 {
+		return CONSOLE_SCREEN_BUFFER_INFO{}, err
+	}
+	return info, nil
+}
+
+// ConsoleClearScreen blanks the whole screen buffer of standard output and moves the cursor to the top-left corner.
+func ConsoleClearScreen() error {
+	stdout, err := windows.GetStdHandle(windows.STD_OUTPUT_HANDLE)
+	if err != nil {
+		return err
+	}
+	info, err := GetConsoleScreenBufferInfo(stdout)
+	if err != nil {
+		return err
+	}
+
+	cells := uint32(info.Size.X) * uint32(info.Size.Y)
+	var written uint32
+	r1, _, err := Kernel32.NewProc("FillConsoleOutputCharacterW").Call(uintptr(stdout), ' ', uintptr(cells),
+		coordArg(COORD{}), uintptr(unsafe.Pointer(&written)))
+	if r1 == 0 {
+		return err
+	}
+	r1, _, err = Kernel32.NewProc("FillConsoleOutputAttribute").Call(uintptr(stdout), uintptr(info.Attributes), uintptr(cells),
+		coordArg(COORD{}), uintptr(unsafe.Pointer(&written)))
+	if r1 == 0 {
+		return err
+	}
+	return ConsoleGoToXY(0, 0)
+}
+
+// ConsoleGoToXY moves the cursor of standard output to column x and row y of the screen buffer.
+func ConsoleGoToXY(x, y int16) error {
+	stdout, err := windows.GetStdHandle(windows.STD_OUTPUT_HANDLE)
+	if err != nil {
+		return err
+	}
+	r1, _, err := Kernel32.NewProc("SetConsoleCursorPosition").Call(uintptr(stdout), coordArg(COORD{X: x, Y: y}))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// ConsoleSetSize resizes both the screen buffer and the window of standard output to cols by rows cells.
+func ConsoleSetSize(cols, rows int16) error {
+	stdout, err := windows.GetStdHandle(windows.STD_OUTPUT_HANDLE)
+	if err != nil {
+		return err
+	}
+
+	// The window can never be larger than the buffer, so shrink it first,
+	// then resize the buffer and grow the window to match.
+	window := SMALL_RECT{}
+	r1, _, err := Kernel32.NewProc("SetConsoleWindowInfo").Call(uintptr(stdout), 1, uintptr(unsafe.Pointer(&window)))
+	if r1 == 0 {
+		return err
+	}
+	r1, _, err = Kernel32.NewProc("SetConsoleScreenBufferSize").Call(uintptr(stdout), coordArg(COORD{X: cols, Y: rows}))
+	if r1 == 0 {
+		return err
+	}
+	window = SMALL_RECT{Right: cols - 1, Bottom: rows - 1}
+	r1, _, err = Kernel32.NewProc("SetConsoleWindowInfo").Call(uintptr(stdout), 1, uintptr(unsafe.Pointer(&window)))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// coordArg packs a COORD into a single argument, since COORD is passed by value.
+func coordArg(c COORD) uintptr {
+	return uintptr(uint16(c.X)) | uintptr(uint16(c.Y))<<16
+}
+
+// CHAR_INFO specifies a Unicode character and its attributes in a console screen buffer.
+type CHAR_INFO struct {
+	Char       uint16
+	Attributes uint16
+}
+
+// WriteConsoleOutputW writes cells, laid out as a bufSize grid, into writeRegion of the screen buffer.
+// bufCoord is the top-left cell of cells to copy from. It returns the region actually written.
+func WriteConsoleOutputW(handle windows.Handle, cells []CHAR_INFO, bufSize, bufCoord COORD, writeRegion *SMALL_RECT) (SMALL_RECT, error) {
+	if len(cells) == 0 || len(cells) < int(bufSize.X)*int(bufSize.Y) {
+		return SMALL_RECT{}, windows.ERROR_INVALID_PARAMETER
+	}
+	region := *writeRegion
+	r1, _, err := Kernel32.NewProc("WriteConsoleOutputW").Call(uintptr(handle), uintptr(unsafe.Pointer(&cells[0])),
+		coordArg(bufSize), coordArg(bufCoord), uintptr(unsafe.Pointer(&region)))
+	if r1 == 0 {
+		return SMALL_RECT{}, err
+	}
+	return region, nil
+}
+
+// ReadConsoleOutputW reads readRegion of the screen buffer into a new bufSize grid of cells,
+// starting at bufCoord. It returns the cells and the region actually read.
+func ReadConsoleOutputW(handle windows.Handle, bufSize, bufCoord COORD, readRegion SMALL_RECT) ([]CHAR_INFO, SMALL_RECT, error) {
+	if bufSize.X <= 0 || bufSize.Y <= 0 {
+		return nil, SMALL_RECT{}, windows.ERROR_INVALID_PARAMETER
+	}
+	cells := make([]CHAR_INFO, int(bufSize.X)*int(bufSize.Y))
+	r1, _, err := Kernel32.NewProc("ReadConsoleOutputW").Call(uintptr(handle), uintptr(unsafe.Pointer(&cells[0])),
+		coordArg(bufSize), coordArg(bufCoord), uintptr(unsafe.Pointer(&readRegion)))
+	if r1 == 0 {
+		return nil, SMALL_RECT{}, err
+	}
+	return cells, readRegion, nil
+}
@@ -0,0 +1,153 @@
+package win32utils
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+	"testing"
+
+	"golang.org/x/sys/windows"
+)
+
+func TestGetConsoleProcessList(t *testing.T) {
+	if GetConsoleWindows() == 0 {
+		t.Skip("no console attached")
+	}
+
+	pids, err := GetConsoleProcessList()
+	if err != nil {
+		t.Fatalf("GetConsoleProcessList() error = %v", err)
+	}
+	for _, pid := range pids {
+		if pid == uint32(os.Getpid()) {
+			return
+		}
+	}
+	t.Errorf("GetConsoleProcessList() = %v, want it to contain %d", pids, os.Getpid())
+}
+
+func TestRunningByDoubleClickNoConsole(t *testing.T) {
+	if os.Getenv("WIN32UTILS_TEST_NO_CONSOLE") == "1" {
+		if GetConsoleWindows() != 0 {
+			t.Skip("detached process has a console")
+		}
+		if RunningByDoubleClick() {
+			t.Error("RunningByDoubleClick() without a console = true, want false")
+		}
+		return
+	}
+
+	// Run this test again in a process started without a console.
+	cmd := exec.Command(os.Args[0], "-test.run=^TestRunningByDoubleClickNoConsole$", "-test.v")
+	cmd.Env = append(os.Environ(), "WIN32UTILS_TEST_NO_CONSOLE=1")
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: windows.DETACHED_PROCESS}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Errorf("detached test process error = %v\n%s", err, out)
+	}
+}
+
+func TestAttachConsoleAlreadyAttached(t *testing.T) {
+	if GetConsoleWindows() == 0 {
+		t.Skip("no console attached")
+	}
+	if err := AttachConsole(ATTACH_PARENT_PROCESS); err != windows.ERROR_ACCESS_DENIED {
+		t.Errorf("AttachConsole() with a console error = %v, want %v", err, windows.ERROR_ACCESS_DENIED)
+	}
+}
+
+func TestConsoleCodePages(t *testing.T) {
+	if GetConsoleWindows() == 0 {
+		t.Skip("no console attached")
+	}
+
+	inCP, err := GetConsoleInputCP()
+	if err != nil {
+		t.Fatalf("GetConsoleInputCP() error = %v", err)
+	}
+	outCP, err := GetConsoleOutputCP()
+	if err != nil {
+		t.Fatalf("GetConsoleOutputCP() error = %v", err)
+	}
+	defer SetConsoleInputCP(inCP)
+	defer SetConsoleOutputCP(outCP)
+
+	if err := SetConsoleInputCP(CP_UTF8); err != nil {
+		t.Fatalf("SetConsoleInputCP() error = %v", err)
+	}
+	if err := SetConsoleOutputCP(CP_UTF8); err != nil {
+		t.Fatalf("SetConsoleOutputCP() error = %v", err)
+	}
+	if got, _ := GetConsoleInputCP(); got != CP_UTF8 {
+		t.Errorf("GetConsoleInputCP() = %d, want %d", got, CP_UTF8)
+	}
+	if got, _ := GetConsoleOutputCP(); got != CP_UTF8 {
+		t.Errorf("GetConsoleOutputCP() = %d, want %d", got, CP_UTF8)
+	}
+}
+
+func TestEnableVirtualTerminalProcessing(t *testing.T) {
+	stdout, _ := windows.GetStdHandle(windows.STD_OUTPUT_HANDLE)
+	mode, err := GetConsoleMode(stdout)
+	if err != nil {
+		t.Skip("standard output is not a console")
+	}
+	defer SetConsoleMode(stdout, mode)
+
+	if err := EnableVirtualTerminalProcessing(); err != nil {
+		t.Fatalf("EnableVirtualTerminalProcessing() error = %v", err)
+	}
+	got, err := GetConsoleMode(stdout)
+	if err != nil {
+		t.Fatalf("GetConsoleMode() error = %v", err)
+	}
+	if got&ENABLE_VIRTUAL_TERMINAL_PROCESSING == 0 {
+		t.Errorf("GetConsoleMode() = %#x, want ENABLE_VIRTUAL_TERMINAL_PROCESSING set", got)
+	}
+}
+
+func TestConsoleGoToXY(t *testing.T) {
+	stdout, _ := windows.GetStdHandle(windows.STD_OUTPUT_HANDLE)
+	info, err := GetConsoleScreenBufferInfo(stdout)
+	if err != nil {
+		t.Skip("standard output is not a console")
+	}
+
+	if err := ConsoleGoToXY(0, 0); err != nil {
+		t.Errorf("ConsoleGoToXY() error = %v", err)
+	}
+	ConsoleGoToXY(info.CursorPosition.X, info.CursorPosition.Y)
+}
+
+func TestConsoleOutputRoundTrip(t *testing.T) {
+	stdout, _ := windows.GetStdHandle(windows.STD_OUTPUT_HANDLE)
+	info, err := GetConsoleScreenBufferInfo(stdout)
+	if err != nil {
+		t.Skip("standard output is not a console")
+	}
+
+	size := COORD{X: 5, Y: 1}
+	region := SMALL_RECT{Left: 0, Top: info.Window.Top, Right: 4, Bottom: info.Window.Top}
+	saved, _, err := ReadConsoleOutputW(stdout, size, COORD{}, region)
+	if err != nil {
+		t.Fatalf("ReadConsoleOutputW() error = %v", err)
+	}
+	defer WriteConsoleOutputW(stdout, saved, size, COORD{}, &region)
+
+	cells := make([]CHAR_INFO, 5)
+	for i, c := range "Hello" {
+		cells[i] = CHAR_INFO{Char: uint16(c), Attributes: info.Attributes}
+	}
+	if _, err := WriteConsoleOutputW(stdout, cells, size, COORD{}, &region); err != nil {
+		t.Fatalf("WriteConsoleOutputW() error = %v", err)
+	}
+
+	got, _, err := ReadConsoleOutputW(stdout, size, COORD{}, region)
+	if err != nil {
+		t.Fatalf("ReadConsoleOutputW() error = %v", err)
+	}
+	for i := range cells {
+		if got[i].Char != cells[i].Char {
+			t.Errorf("cell %d = %q, want %q", i, rune(got[i].Char), rune(cells[i].Char))
+		}
+	}
+}
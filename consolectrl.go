@@ -0,0 +1,98 @@
+package win32utils
+
+import (
+	"reflect"
+	"sync"
+
+	"golang.org/x/sys/windows"
+)
+
+// Control signals passed to console control handlers.
+const (
+	CTRL_C_EVENT        uint32 = 0
+	CTRL_BREAK_EVENT    uint32 = 1
+	CTRL_CLOSE_EVENT    uint32 = 2
+	CTRL_LOGOFF_EVENT   uint32 = 5
+	CTRL_SHUTDOWN_EVENT uint32 = 6
+)
+
+var (
+	consoleCtrlMu sync.Mutex
+	// consoleCtrlHandlers holds the registered handlers in registration order. Keeping them
+	// here also keeps them reachable while the system may call them.
+	consoleCtrlHandlers []func(ctrlType uint32) bool
+)
+
+// consoleCtrlCallback is registered with kernel32 once and dispatches to consoleCtrlHandlers;
+// see globalWndProcCallback.
+var consoleCtrlCallback = windows.NewCallback(func(ctrlType uint32) uintptr {
+	if dispatchConsoleCtrl(ctrlType) {
+		return 1
+	}
+	return 0
+})
+
+// dispatchConsoleCtrl calls the handlers, the most recently registered first, until one returns true.
+func dispatchConsoleCtrl(ctrlType uint32) bool {
+	consoleCtrlMu.Lock()
+	handlers := append([]func(uint32) bool(nil), consoleCtrlHandlers...)
+	consoleCtrlMu.Unlock()
+	for i := len(handlers) - 1; i >= 0; i-- {
+		if handlers[i](ctrlType) {
+			return true
+		}
+	}
+	return false
+}
+
+func setConsoleCtrlCallback(add bool) error {
+	var fAdd uintptr
+	if add {
+		fAdd = 1
+	}
+	r1, _, err := Kernel32.NewProc("SetConsoleCtrlHandler").Call(consoleCtrlCallback, fAdd)
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// SetConsoleCtrlHandler adds handler to the handlers called when the console delivers a CTRL_
+// signal, such as Ctrl+C in the console window or the console being closed. Handlers run on a
+// thread created by the system, the most recently added first; returning true stops the signal
+// from reaching earlier handlers and the default handler, which ends the process.
+//
+// Unlike signal.Notify, which takes part in the same chain through the Go runtime's own handler,
+// the handler receives the raw control type and can suppress it. It works whenever the process
+// is attached to a console, including under go run. After CTRL_CLOSE_EVENT, CTRL_LOGOFF_EVENT
+// and CTRL_SHUTDOWN_EVENT the system ends the process once the handler returns.
+func SetConsoleCtrlHandler(handler func(ctrlType uint32) bool) error {
+	consoleCtrlMu.Lock()
+	defer consoleCtrlMu.Unlock()
+	if len(consoleCtrlHandlers) == 0 {
+		if err := setConsoleCtrlCallback(true); err != nil {
+			return err
+		}
+	}
+	consoleCtrlHandlers = append(consoleCtrlHandlers, handler)
+	return nil
+}
+
+// RemoveConsoleCtrlHandler removes the most recently added handler with the same function
+// as handler. Closures created by the same function literal are not distinguished.
+func RemoveConsoleCtrlHandler(handler func(ctrlType uint32) bool) error {
+	consoleCtrlMu.Lock()
+	defer consoleCtrlMu.Unlock()
+	fn := reflect.ValueOf(handler).Pointer()
+	for i := len(consoleCtrlHandlers) - 1; i >= 0; i-- {
+		if reflect.ValueOf(consoleCtrlHandlers[i]).Pointer() != fn {
+			continue
+		}
+		consoleCtrlHandlers = append(consoleCtrlHandlers[:i], consoleCtrlHandlers[i+1:]...)
+		if len(consoleCtrlHandlers) == 0 {
+			return setConsoleCtrlCallback(false)
+		}
+		return nil
+	}
+	return windows.ERROR_INVALID_PARAMETER
+}
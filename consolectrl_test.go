@@ -0,0 +1,55 @@
+package win32utils
+
+import (
+	"testing"
+
+	"golang.org/x/sys/windows"
+)
+
+var consoleCtrlCalls []string
+
+func consoleCtrlFirst(ctrlType uint32) bool {
+	consoleCtrlCalls = append(consoleCtrlCalls, "first")
+	return true
+}
+
+func consoleCtrlSecond(ctrlType uint32) bool {
+	consoleCtrlCalls = append(consoleCtrlCalls, "second")
+	return ctrlType == CTRL_BREAK_EVENT
+}
+
+func TestSetConsoleCtrlHandler(t *testing.T) {
+	if err := SetConsoleCtrlHandler(consoleCtrlFirst); err != nil {
+		t.Fatalf("SetConsoleCtrlHandler() error = %v", err)
+	}
+	if err := SetConsoleCtrlHandler(consoleCtrlSecond); err != nil {
+		t.Fatalf("SetConsoleCtrlHandler() error = %v", err)
+	}
+
+	consoleCtrlCalls = nil
+	if !dispatchConsoleCtrl(CTRL_BREAK_EVENT) {
+		t.Error("dispatchConsoleCtrl(CTRL_BREAK_EVENT) = false, want true")
+	}
+	if len(consoleCtrlCalls) != 1 || consoleCtrlCalls[0] != "second" {
+		t.Errorf("handlers called = %v, want [second]", consoleCtrlCalls)
+	}
+
+	consoleCtrlCalls = nil
+	dispatchConsoleCtrl(CTRL_C_EVENT)
+	if len(consoleCtrlCalls) != 2 || consoleCtrlCalls[1] != "first" {
+		t.Errorf("handlers called = %v, want [second first]", consoleCtrlCalls)
+	}
+
+	if err := RemoveConsoleCtrlHandler(consoleCtrlFirst); err != nil {
+		t.Errorf("RemoveConsoleCtrlHandler() error = %v", err)
+	}
+	if err := RemoveConsoleCtrlHandler(consoleCtrlFirst); err != windows.ERROR_INVALID_PARAMETER {
+		t.Errorf("second RemoveConsoleCtrlHandler() error = %v, want %v", err, windows.ERROR_INVALID_PARAMETER)
+	}
+	if err := RemoveConsoleCtrlHandler(consoleCtrlSecond); err != nil {
+		t.Errorf("RemoveConsoleCtrlHandler() error = %v", err)
+	}
+	if dispatchConsoleCtrl(CTRL_C_EVENT) {
+		t.Error("dispatchConsoleCtrl() with no handlers = true")
+	}
+}
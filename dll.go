@@ -2,5 +2,19 @@ package win32utils
 
 import "golang.org/x/sys/windows"
 
-var Kernel32 = windows.NewLazySystemDLL("kernel32.dll")
-var User32 = windows.NewLazySystemDLL("user32.dll")
+// System DLLs used by this package. Every procedure is looked up through one of these,
+// so each DLL is loaded at most once and only when first needed.
+var (
+	Kernel32 = windows.NewLazySystemDLL("kernel32.dll")
+	User32   = windows.NewLazySystemDLL("user32.dll")
+	Gdi32    = windows.NewLazySystemDLL("gdi32.dll")
+	Ole32    = windows.NewLazySystemDLL("ole32.dll")
+	Dwmapi   = windows.NewLazySystemDLL("dwmapi.dll")
+	Winhttp  = windows.NewLazySystemDLL("winhttp.dll")
+	Shell32  = windows.NewLazySystemDLL("shell32.dll")
+	Msimg32  = windows.NewLazySystemDLL("msimg32.dll")
+	Comctl32 = windows.NewLazySystemDLL("comctl32.dll")
+	Ntdll    = windows.NewLazySystemDLL("ntdll.dll")
+	Psapi    = windows.NewLazySystemDLL("psapi.dll")
+	Advapi32 = windows.NewLazySystemDLL("advapi32.dll")
+)
@@ -0,0 +1,102 @@
+package win32utils
+
+import "golang.org/x/sys/windows"
+
+// USER_DEFAULT_SCREEN_DPI is the DPI at 100% scaling.
+const USER_DEFAULT_SCREEN_DPI uint32 = 96
+
+// GetWindowDPI returns the DPI of hwnd, or of the system if hwnd is 0.
+// It tries GetDpiForWindow (Windows 10 1607+), then GetDpiForSystem,
+// then GetDeviceCaps(LOGPIXELSX) on the window's DC, and returns
+// USER_DEFAULT_SCREEN_DPI if none of them succeed.
+func GetWindowDPI(hwnd windows.HWND) uint32 {
+	if hwnd != 0 {
+		proc := User32.NewProc("GetDpiForWindow")
+		if proc.Find() == nil {
+			if dpi, _, _ := proc.Call(uintptr(hwnd)); dpi != 0 {
+				return uint32(dpi)
+			}
+		}
+	}
+
+	proc := User32.NewProc("GetDpiForSystem")
+	if proc.Find() == nil {
+		if dpi, _, _ := proc.Call(); dpi != 0 {
+			return uint32(dpi)
+		}
+	}
+
+	hdc, err := GetDC(hwnd)
+	if err == nil {
+		defer ReleaseDC(hwnd, hdc)
+		if dpi := GetDeviceCaps(hdc, LOGPIXELSX); dpi > 0 {
+			return uint32(dpi)
+		}
+	}
+
+	return USER_DEFAULT_SCREEN_DPI
+}
+
+// GetDpiForWindow returns the DPI of the monitor hwnd is on, as reported by GetDpiForWindow
+// (Windows 10 1607+). On older systems it falls back to GetDpiForSystem.
+//
+// The DPI of a window changes when it moves to a monitor with a different scale. The window then
+// receives WM_DPICHANGED with the new DPI in the low word of wParam and a pointer to a suggested
+// RECT in lParam; WndProc handlers should pass that rect to SetWindowPos with
+// SWP_NOZORDER|SWP_NOACTIVATE and recompute any sizes derived from the DPI.
+func GetDpiForWindow(hwnd windows.HWND) (uint32, error) {
+	proc := User32.NewProc("GetDpiForWindow")
+	if proc.Find() != nil {
+		proc = User32.NewProc("GetDpiForSystem")
+		if err := proc.Find(); err != nil {
+			return 0, err
+		}
+		dpi, _, _ := proc.Call()
+		return uint32(dpi), nil
+	}
+	dpi, _, _ := proc.Call(uintptr(hwnd))
+	if dpi == 0 {
+		return 0, ErrInvalidWindowHandle
+	}
+	return uint32(dpi), nil
+}
+
+// ScaleXForWindow scales the horizontal distance x, given at 96 DPI, to the DPI of hwnd.
+// It returns x unchanged if the DPI cannot be determined.
+func ScaleXForWindow(hwnd windows.HWND, x int32) int32 {
+	dpi, err := GetDpiForWindow(hwnd)
+	if err != nil {
+		return x
+	}
+	return scaleForDPI(x, dpi)
+}
+
+// ScaleYForWindow scales the vertical distance y, given at 96 DPI, to the DPI of hwnd.
+// Windows uses the same DPI on both axes, so it is equivalent to ScaleXForWindow.
+func ScaleYForWindow(hwnd windows.HWND, y int32) int32 {
+	return ScaleXForWindow(hwnd, y)
+}
+
+// ScaleRectForWindow scales every coordinate of r, given at 96 DPI, to the DPI of hwnd.
+func ScaleRectForWindow(hwnd windows.HWND, r RECT) RECT {
+	dpi, err := GetDpiForWindow(hwnd)
+	if err != nil {
+		return r
+	}
+	return RECT{
+		Left:   scaleForDPI(r.Left, dpi),
+		Top:    scaleForDPI(r.Top, dpi),
+		Right:  scaleForDPI(r.Right, dpi),
+		Bottom: scaleForDPI(r.Bottom, dpi),
+	}
+}
+
+// scaleForDPI converts v from USER_DEFAULT_SCREEN_DPI to dpi, rounding half away from zero like MulDiv.
+func scaleForDPI(v int32, dpi uint32) int32 {
+	n := int64(v) * int64(dpi)
+	d := int64(USER_DEFAULT_SCREEN_DPI)
+	if n < 0 {
+		return int32((n - d/2) / d)
+	}
+	return int32((n + d/2) / d)
+}
@@ -0,0 +1,54 @@
+package win32utils
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestGetDpiForWindow(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	hwnd, err := CreateWindowExW(0, "STATIC", "", WS_POPUP, 0, 0, 10, 10, 0, 0, moduleHandle(), 0)
+	if err != nil {
+		t.Fatalf("CreateWindowExW() error = %v", err)
+	}
+	defer DestroyWindow(hwnd)
+
+	dpi, err := GetDpiForWindow(hwnd)
+	if err != nil {
+		t.Fatalf("GetDpiForWindow() error = %v", err)
+	}
+	if dpi < USER_DEFAULT_SCREEN_DPI {
+		t.Errorf("GetDpiForWindow() = %d, want at least %d", dpi, USER_DEFAULT_SCREEN_DPI)
+	}
+	if got, want := ScaleXForWindow(hwnd, 100), scaleForDPI(100, dpi); got != want {
+		t.Errorf("ScaleXForWindow(100) = %d, want %d", got, want)
+	}
+}
+
+func TestGetWindowDPI(t *testing.T) {
+	// With no window, GetWindowDPI falls back to the system DPI.
+	if dpi := GetWindowDPI(0); dpi < 48 || dpi > 480 {
+		t.Errorf("GetWindowDPI(0) = %d, want between 48 and 480", dpi)
+	}
+}
+
+func TestScaleForDPI(t *testing.T) {
+	tests := []struct {
+		v    int32
+		dpi  uint32
+		want int32
+	}{
+		{100, 96, 100},
+		{100, 144, 150},
+		{100, 192, 200},
+		{15, 120, 19},
+		{-15, 120, -19},
+	}
+	for _, tt := range tests {
+		if got := scaleForDPI(tt.v, tt.dpi); got != tt.want {
+			t.Errorf("scaleForDPI(%d, %d) = %d, want %d", tt.v, tt.dpi, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package win32utils
+
+import (
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Drop effects reported to the drag source.
+const (
+	DROPEFFECT_NONE uint32 = 0
+	DROPEFFECT_COPY uint32 = 1
+	DROPEFFECT_MOVE uint32 = 2
+	DROPEFFECT_LINK uint32 = 4
+)
+
+const CF_HDROP uint16 = 15
+
+const (
+	DVASPECT_CONTENT uint32 = 1
+	TYMED_HGLOBAL    uint32 = 1
+)
+
+const (
+	S_OK          uintptr = 0
+	E_NOINTERFACE uintptr = 0x80004002
+)
+
+var (
+	IID_IUnknown    = windows.GUID{Data1: 0x00000000, Data2: 0x0000, Data3: 0x0000, Data4: [8]byte{0xC0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x46}}
+	IID_IDropTarget = windows.GUID{Data1: 0x00000122, Data2: 0x0000, Data3: 0x0000, Data4: [8]byte{0xC0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x46}}
+)
+
+// IDataObject vtable offsets.
+const (
+	dataObjectGetData      = 3
+	dataObjectQueryGetData = 5
+)
+
+// FORMATETC describes a clipboard format for IDataObject.
+type FORMATETC struct {
+	CfFormat uint16
+	Ptd      uintptr
+	DwAspect uint32
+	Lindex   int32
+	Tymed    uint32
+}
+
+// STGMEDIUM is the storage medium returned by IDataObject.GetData.
+type STGMEDIUM struct {
+	Tymed          uint32
+	HGlobal        uintptr
+	PUnkForRelease uintptr
+}
+
+// dropTargetVtbl matches the IDropTarget vtable: the IUnknown methods followed by
+// DragEnter, DragOver, DragLeave and Drop.
+type dropTargetVtbl struct {
+	QueryInterface uintptr
+	AddRef         uintptr
+	Release        uintptr
+	DragEnter      uintptr
+	DragOver       uintptr
+	DragLeave      uintptr
+	Drop           uintptr
+}
+
+// The DragEnter, DragOver and Drop callbacks take a POINTL by value, whose layout on the
+// stack depends on the architecture; they are declared in droptarget_pt64.go and droptarget_pt32.go.
+var dropTargetVtblInstance = dropTargetVtbl{
+	QueryInterface: windows.NewCallback(func(this *DropTarget, riid *windows.GUID, ppv *uintptr) uintptr {
+		if *riid != IID_IUnknown && *riid != IID_IDropTarget {
+			*ppv = 0
+			return E_NOINTERFACE
+		}
+		atomic.AddInt32(&this.refs, 1)
+		*ppv = uintptr(unsafe.Pointer(this))
+		return S_OK
+	}),
+	AddRef: windows.NewCallback(func(this *DropTarget) uintptr {
+		return uintptr(atomic.AddInt32(&this.refs, 1))
+	}),
+	Release: windows.NewCallback(func(this *DropTarget) uintptr {
+		return uintptr(atomic.AddInt32(&this.refs, -1))
+	}),
+	DragEnter: dragEnterCallback,
+	DragOver:  dragOverCallback,
+	DragLeave: windows.NewCallback(func(this *DropTarget) uintptr {
+		this.accept = false
+		return S_OK
+	}),
+	Drop: dropCallback,
+}
+
+func (t *DropTarget) dragEnter(dataObj *comObject, effect *uint32) uintptr {
+	t.accept = hasHDROP(dataObj)
+	*effect = t.effect()
+	return S_OK
+}
+
+func (t *DropTarget) dragOver(effect *uint32) uintptr {
+	*effect = t.effect()
+	return S_OK
+}
+
+func (t *DropTarget) drop(dataObj *comObject, effect *uint32) uintptr {
+	*effect = t.effect()
+	t.accept = false
+	if *effect == DROPEFFECT_NONE || t.OnDrop == nil {
+		return S_OK
+	}
+	files, err := dataObjectFiles(dataObj)
+	if err == nil {
+		t.OnDrop(files)
+	}
+	return S_OK
+}
+
+// DropTarget implements the IDropTarget COM interface for windows that accept files
+// dragged from Explorer. OnDrop receives the paths of the dropped files.
+type DropTarget struct {
+	vtbl   *dropTargetVtbl
+	refs   int32
+	accept bool
+
+	OnDrop func(files []string)
+}
+
+// NewDropTarget returns a DropTarget that calls onDrop with the dropped file paths.
+func NewDropTarget(onDrop func(files []string)) *DropTarget {
+	return &DropTarget{vtbl: &dropTargetVtblInstance, OnDrop: onDrop}
+}
+
+func (t *DropTarget) effect() uint32 {
+	if t.accept {
+		return DROPEFFECT_COPY
+	}
+	return DROPEFFECT_NONE
+}
+
+var (
+	dropTargetsMu sync.Mutex
+	// dropTargets keeps registered targets reachable while the system holds a pointer to them.
+	dropTargets = make(map[windows.HWND]*DropTarget)
+)
+
+// RegisterDragDrop registers hwnd as a target of OLE drag-and-drop operations.
+// OleInitialize must have been called on the window's thread first, and that thread needs a message loop.
+func RegisterDragDrop(hwnd windows.HWND, target *DropTarget) error {
+	if target.vtbl == nil {
+		target.vtbl = &dropTargetVtblInstance
+	}
+
+	r1, _, _ := Ole32.NewProc("RegisterDragDrop").Call(uintptr(hwnd), uintptr(unsafe.Pointer(target)))
+	if err := hresultError(r1); err != nil {
+		return err
+	}
+
+	dropTargetsMu.Lock()
+	dropTargets[hwnd] = target
+	dropTargetsMu.Unlock()
+	return nil
+}
+
+// RevokeDragDrop revokes the registration of hwnd as a drop target.
+func RevokeDragDrop(hwnd windows.HWND) error {
+	r1, _, _ := Ole32.NewProc("RevokeDragDrop").Call(uintptr(hwnd))
+	if err := hresultError(r1); err != nil {
+		return err
+	}
+
+	dropTargetsMu.Lock()
+	delete(dropTargets, hwnd)
+	dropTargetsMu.Unlock()
+	return nil
+}
+
+func hdropFormat() FORMATETC {
+	return FORMATETC{
+		CfFormat: CF_HDROP,
+		DwAspect: DVASPECT_CONTENT,
+		Lindex:   -1,
+		Tymed:    TYMED_HGLOBAL,
+	}
+}
+
+// hasHDROP reports whether the data object can provide CF_HDROP.
+func hasHDROP(dataObj *comObject) bool {
+	format := hdropFormat()
+	r1, _, _ := syscall.SyscallN(dataObj.method(dataObjectQueryGetData),
+		uintptr(unsafe.Pointer(dataObj)),
+		uintptr(unsafe.Pointer(&format)))
+	return r1 == S_OK
+}
+
+// dataObjectFiles extracts the file paths from the CF_HDROP data of a data object.
+func dataObjectFiles(dataObj *comObject) ([]string, error) {
+	format := hdropFormat()
+	var medium STGMEDIUM
+	r1, _, _ := syscall.SyscallN(dataObj.method(dataObjectGetData),
+		uintptr(unsafe.Pointer(dataObj)),
+		uintptr(unsafe.Pointer(&format)),
+		uintptr(unsafe.Pointer(&medium)))
+	if err := hresultError(r1); err != nil {
+		return nil, err
+	}
+	defer Ole32.NewProc("ReleaseStgMedium").Call(uintptr(unsafe.Pointer(&medium)))
+
+	return dragQueryFiles(medium.HGlobal)
+}
+
+// dragQueryFiles returns the file names stored in an HDROP.
+func dragQueryFiles(hDrop uintptr) ([]string, error) {
+	proc := Shell32.NewProc("DragQueryFileW")
+	count, _, err := proc.Call(hDrop, 0xFFFFFFFF, 0, 0)
+	if count == 0 && err != windows.ERROR_SUCCESS {
+		return nil, err
+	}
+
+	files := make([]string, 0, count)
+	for i := uintptr(0); i < count; i++ {
+		n, _, err := proc.Call(hDrop, i, 0, 0)
+		if n == 0 {
+			return nil, err
+		}
+		buf := make([]uint16, n+1)
+		n, _, err = proc.Call(hDrop, i, uintptr(unsafe.Pointer(&buf[0])), uintptr(len(buf)))
+		if n == 0 {
+			return nil, err
+		}
+		files = append(files, windows.UTF16ToString(buf[:n]))
+	}
+	return files, nil
+}
+
+// DROPFILES is the header of the global memory block behind an HDROP.
+// It is followed by the double-null-terminated list of file names.
+type DROPFILES struct {
+	PFiles uint32
+	Pt     POINT
+	FNC    int32
+	FWide  int32
+}
+
+// DragAcceptFiles registers whether hwnd accepts dropped files through WM_DROPFILES.
+// This is the legacy alternative to RegisterDragDrop and does not need OLE.
+func DragAcceptFiles(hwnd windows.HWND, accept bool) {
+	var fAccept uintptr
+	if accept {
+		fAccept = 1
+	}
+	_, _, _ = Shell32.NewProc("DragAcceptFiles").Call(uintptr(hwnd), fAccept)
+}
+
+// ExtractDroppedFiles returns the file names of the HDROP delivered in the wParam of WM_DROPFILES
+// and releases it with DragFinish. hDrop must not be used afterwards.
+func ExtractDroppedFiles(hDrop uintptr) ([]string, error) {
+	defer Shell32.NewProc("DragFinish").Call(hDrop)
+	return dragQueryFiles(hDrop)
+}
+
+// SetDropFilesHandler makes hwnd accept dropped files and calls fn with their paths on WM_DROPFILES.
+// The HDROP is released, so WM_DROPFILES is not passed on to the window procedure.
+func SetDropFilesHandler(hwnd windows.HWND, fn func(files []string)) {
+	ChainWndProc(hwnd, func(hwnd windows.HWND, msg uint32, wParam, lParam uintptr) (uintptr, bool) {
+		if msg != WM_DROPFILES {
+			return 0, false
+		}
+		files, err := ExtractDroppedFiles(wParam)
+		if err == nil {
+			fn(files)
+		}
+		return 0, true
+	})
+	DragAcceptFiles(hwnd, true)
+}
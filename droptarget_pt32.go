@@ -0,0 +1,18 @@
+//go:build 386
+
+package win32utils
+
+import "golang.org/x/sys/windows"
+
+// On 32-bit Windows the POINTL argument takes two parameter slots, one for x and one for y.
+var (
+	dragEnterCallback = windows.NewCallback(func(this *DropTarget, dataObj *comObject, keyState uint32, x, y int32, effect *uint32) uintptr {
+		return this.dragEnter(dataObj, effect)
+	})
+	dragOverCallback = windows.NewCallback(func(this *DropTarget, keyState uint32, x, y int32, effect *uint32) uintptr {
+		return this.dragOver(effect)
+	})
+	dropCallback = windows.NewCallback(func(this *DropTarget, dataObj *comObject, keyState uint32, x, y int32, effect *uint32) uintptr {
+		return this.drop(dataObj, effect)
+	})
+)
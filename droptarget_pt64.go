@@ -0,0 +1,18 @@
+//go:build amd64 || arm64
+
+package win32utils
+
+import "golang.org/x/sys/windows"
+
+// On 64-bit Windows the POINTL argument fits into a single register-sized parameter.
+var (
+	dragEnterCallback = windows.NewCallback(func(this *DropTarget, dataObj *comObject, keyState uint32, pt uintptr, effect *uint32) uintptr {
+		return this.dragEnter(dataObj, effect)
+	})
+	dragOverCallback = windows.NewCallback(func(this *DropTarget, keyState uint32, pt uintptr, effect *uint32) uintptr {
+		return this.dragOver(effect)
+	})
+	dropCallback = windows.NewCallback(func(this *DropTarget, dataObj *comObject, keyState uint32, pt uintptr, effect *uint32) uintptr {
+		return this.drop(dataObj, effect)
+	})
+)
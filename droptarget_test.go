@@ -0,0 +1,118 @@
+package win32utils
+
+import (
+	"reflect"
+	"runtime"
+	"testing"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+func TestDropTargetVtblLayout(t *testing.T) {
+	if off := unsafe.Offsetof(DropTarget{}.vtbl); off != 0 {
+		t.Fatalf("DropTarget.vtbl offset = %d, want 0", off)
+	}
+
+	var vtbl dropTargetVtbl
+	ptr := unsafe.Sizeof(uintptr(0))
+	methods := []struct {
+		name   string
+		offset uintptr
+	}{
+		{"QueryInterface", unsafe.Offsetof(vtbl.QueryInterface)},
+		{"AddRef", unsafe.Offsetof(vtbl.AddRef)},
+		{"Release", unsafe.Offsetof(vtbl.Release)},
+		{"DragEnter", unsafe.Offsetof(vtbl.DragEnter)},
+		{"DragOver", unsafe.Offsetof(vtbl.DragOver)},
+		{"DragLeave", unsafe.Offsetof(vtbl.DragLeave)},
+		{"Drop", unsafe.Offsetof(vtbl.Drop)},
+	}
+	for i, m := range methods {
+		if want := uintptr(i) * ptr; m.offset != want {
+			t.Errorf("%s offset = %d, want %d", m.name, m.offset, want)
+		}
+	}
+	if size := unsafe.Sizeof(vtbl); size != uintptr(len(methods))*ptr {
+		t.Errorf("vtable size = %d, want %d", size, uintptr(len(methods))*ptr)
+	}
+}
+
+// newTestHDROP builds an HDROP the same way the shell does: a DROPFILES header
+// followed by the double-null-terminated wide file names.
+func newTestHDROP(t *testing.T, files []string) windows.Handle {
+	t.Helper()
+
+	var names []uint16
+	for _, f := range files {
+		u16, err := windows.UTF16FromString(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, u16...)
+	}
+	names = append(names, 0)
+
+	header := uint(unsafe.Sizeof(DROPFILES{}))
+	size := header + uint(len(names))*2
+	h, err := GlobalAlloc(uint(GMEM_MOVEABLE), size)
+	if err != nil {
+		t.Fatalf("GlobalAlloc() error = %v", err)
+	}
+	p, err := GlobalLock(h)
+	if err != nil {
+		t.Fatalf("GlobalLock() error = %v", err)
+	}
+	buf := unsafe.Slice((*byte)(unsafe.Pointer(p)), size)
+	*(*DROPFILES)(unsafe.Pointer(&buf[0])) = DROPFILES{PFiles: uint32(header), FWide: 1}
+	copy(unsafe.Slice((*uint16)(unsafe.Pointer(&buf[header])), len(names)), names)
+	GlobalUnlock(h)
+	return h
+}
+
+func TestExtractDroppedFiles(t *testing.T) {
+	want := []string{`C:\first.txt`, `C:\dir\second file.txt`}
+	h := newTestHDROP(t, want)
+
+	got, err := ExtractDroppedFiles(uintptr(h))
+	if err != nil {
+		t.Fatalf("ExtractDroppedFiles() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExtractDroppedFiles() = %q, want %q", got, want)
+	}
+}
+
+func TestSetDropFilesHandler(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	var forwarded bool
+	hwnd, err := createManagedWindow("win32utils_test_dropfiles", 0, WS_POPUP, 0,
+		func(hwnd windows.HWND, msg uint32, wParam, lParam uintptr) uintptr {
+			if msg == WM_DROPFILES {
+				forwarded = true
+			}
+			return DefWindowProcW(hwnd, msg, wParam, lParam)
+		})
+	if err != nil {
+		t.Fatalf("createManagedWindow() error = %v", err)
+	}
+	defer DestroyWindow(hwnd)
+
+	var got []string
+	SetDropFilesHandler(hwnd, func(files []string) { got = files })
+	if exStyle, _ := GetWindowLongPtrW(hwnd, GWL_EXSTYLE); uint32(exStyle)&WS_EX_ACCEPTFILES == 0 {
+		t.Errorf("extended style = 0x%X, want WS_EX_ACCEPTFILES", exStyle)
+	}
+
+	want := []string{`C:\dropped.txt`}
+	SendMessageW(hwnd, WM_DROPFILES, uintptr(newTestHDROP(t, want)), 0)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("handler got %q, want %q", got, want)
+	}
+	// The handler released the HDROP, so it must not reach the window procedure.
+	if forwarded {
+		t.Error("WM_DROPFILES was passed on to the window procedure after the HDROP was released")
+	}
+}
@@ -0,0 +1,89 @@
+package win32utils
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Window attributes for DwmSetWindowAttribute.
+const (
+	// DWMWA_USE_IMMERSIVE_DARK_MODE is supported from Windows 10 20H1; earlier builds used 19.
+	DWMWA_USE_IMMERSIVE_DARK_MODE  uint32 = 20
+	DWMWA_WINDOW_CORNER_PREFERENCE uint32 = 33
+	DWMWA_BORDER_COLOR             uint32 = 34
+	DWMWA_CAPTION_COLOR            uint32 = 35
+	DWMWA_TEXT_COLOR               uint32 = 36
+)
+
+// dwmwaUseImmersiveDarkModeBefore20H1 is the undocumented value of DWMWA_USE_IMMERSIVE_DARK_MODE
+// on Windows 10 1809 to 1909.
+const dwmwaUseImmersiveDarkModeBefore20H1 uint32 = 19
+
+// Corner preferences for SetWindowCornerPreference (Windows 11+).
+const (
+	DWMWCP_DEFAULT    uint32 = 0
+	DWMWCP_DONOTROUND uint32 = 1
+	DWMWCP_ROUND      uint32 = 2
+	DWMWCP_ROUNDSMALL uint32 = 3
+)
+
+// Special colors for SetWindowBorderColor.
+const (
+	DWMWA_COLOR_DEFAULT uint32 = 0xFFFFFFFF
+	DWMWA_COLOR_NONE    uint32 = 0xFFFFFFFE
+)
+
+// DwmSetWindowAttribute sets the DWMWA_ attribute of hwnd to the size bytes at value.
+func DwmSetWindowAttribute(hwnd windows.HWND, attribute uint32, value unsafe.Pointer, size uint32) error {
+	r1, _, _ := Dwmapi.NewProc("DwmSetWindowAttribute").Call(uintptr(hwnd), uintptr(attribute), uintptr(value), uintptr(size))
+	return hresultError(r1)
+}
+
+// SetWindowDarkMode switches the title bar of hwnd to dark or light colors (Windows 10 1809+).
+func SetWindowDarkMode(hwnd windows.HWND, dark bool) error {
+	var value int32
+	if dark {
+		value = 1
+	}
+	err := DwmSetWindowAttribute(hwnd, DWMWA_USE_IMMERSIVE_DARK_MODE, unsafe.Pointer(&value), uint32(unsafe.Sizeof(value)))
+	if err != nil {
+		if err2 := DwmSetWindowAttribute(hwnd, dwmwaUseImmersiveDarkModeBefore20H1, unsafe.Pointer(&value), uint32(unsafe.Sizeof(value))); err2 == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// SetWindowCornerPreference sets how the corners of hwnd are rounded to one of the DWMWCP_ values (Windows 11+).
+func SetWindowCornerPreference(hwnd windows.HWND, pref uint32) error {
+	return DwmSetWindowAttribute(hwnd, DWMWA_WINDOW_CORNER_PREFERENCE, unsafe.Pointer(&pref), uint32(unsafe.Sizeof(pref)))
+}
+
+// SetWindowBorderColor sets the border of hwnd to the COLORREF colorRef, or to one of
+// DWMWA_COLOR_DEFAULT and DWMWA_COLOR_NONE (Windows 11+).
+func SetWindowBorderColor(hwnd windows.HWND, colorRef uint32) error {
+	return DwmSetWindowAttribute(hwnd, DWMWA_BORDER_COLOR, unsafe.Pointer(&colorRef), uint32(unsafe.Sizeof(colorRef)))
+}
+
+// GetSystemThemeIsDark reports whether the user has chosen the dark app mode.
+// It reports false without an error on systems that predate the setting.
+func GetSystemThemeIsDark() (bool, error) {
+	key, err := OpenRegistryKey(HKEY_CURRENT_USER, `Software\Microsoft\Windows\CurrentVersion\Themes\Personalize`, KEY_READ)
+	if err == windows.ERROR_FILE_NOT_FOUND {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer key.Close()
+
+	light, err := key.GetDWORD("AppsUseLightTheme")
+	if err == windows.ERROR_FILE_NOT_FOUND {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return light == 0, nil
+}
@@ -0,0 +1,29 @@
+package win32utils
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestSetWindowDarkMode(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	hwnd, err := CreateWindowExW(0, "STATIC", "", WS_OVERLAPPEDWINDOW, 0, 0, 100, 100, 0, 0, moduleHandle(), 0)
+	if err != nil {
+		t.Fatalf("CreateWindowExW() error = %v", err)
+	}
+	defer DestroyWindow(hwnd)
+
+	for _, dark := range []bool{true, false} {
+		if err := SetWindowDarkMode(hwnd, dark); err != nil {
+			t.Errorf("SetWindowDarkMode(%v) error = %v", dark, err)
+		}
+	}
+}
+
+func TestGetSystemThemeIsDark(t *testing.T) {
+	if _, err := GetSystemThemeIsDark(); err != nil {
+		t.Errorf("GetSystemThemeIsDark() error = %v", err)
+	}
+}
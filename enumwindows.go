@@ -0,0 +1,123 @@
+package win32utils
+
+import (
+	"errors"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// ErrWindowNotFound is returned by FindWindowByTitle when no window matches.
+var ErrWindowNotFound = errors.New("win32utils: window not found")
+
+// enumState is passed to enumWindowsCallback through lParam.
+type enumState struct {
+	visit   func(hwnd windows.HWND) bool
+	stopped bool
+	err     error
+}
+
+// enumWindowsCallback is shared by all enumerations; see globalWndProcCallback.
+var enumWindowsCallback = windows.NewCallback(func(hwnd windows.HWND, lParam uintptr) (ret uintptr) {
+	state := (*enumState)(unsafe.Pointer(lParam))
+	defer func() {
+		// Stop the enumeration instead of unwinding through user32.
+		if r := recover(); r != nil {
+			state.stopped = true
+			state.err = errors.New("win32utils: panic during window enumeration")
+			ret = 0
+		}
+	}()
+	if !state.visit(hwnd) {
+		state.stopped = true
+		return 0
+	}
+	return 1
+})
+
+// enumWindows calls visit for every top-level window, or for every descendant of parent
+// if it is not 0, until visit returns false.
+func enumWindows(parent windows.HWND, visit func(hwnd windows.HWND) bool) error {
+	state := &enumState{visit: visit}
+	var r1 uintptr
+	var err error
+	if parent == 0 {
+		r1, _, err = User32.NewProc("EnumWindows").Call(enumWindowsCallback, uintptr(unsafe.Pointer(state)))
+	} else {
+		r1, _, err = User32.NewProc("EnumChildWindows").Call(uintptr(parent), enumWindowsCallback, uintptr(unsafe.Pointer(state)))
+	}
+	if state.err != nil {
+		return state.err
+	}
+	if r1 == 0 && !state.stopped && err != windows.ERROR_SUCCESS {
+		return err
+	}
+	return nil
+}
+
+// EnumWindows returns the handles of all top-level windows on the screen.
+func EnumWindows() ([]windows.HWND, error) {
+	var hwnds []windows.HWND
+	err := enumWindows(0, func(hwnd windows.HWND) bool {
+		hwnds = append(hwnds, hwnd)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return hwnds, nil
+}
+
+// EnumChildWindows returns the handles of all descendants of parent.
+func EnumChildWindows(parent windows.HWND) ([]windows.HWND, error) {
+	var hwnds []windows.HWND
+	err := enumWindows(parent, func(hwnd windows.HWND) bool {
+		hwnds = append(hwnds, hwnd)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return hwnds, nil
+}
+
+// GetWindowTextW retrieves the title of hwnd. For windows of the calling process it sends
+// WM_GETTEXT, so the thread that owns the window must be processing messages.
+func GetWindowTextW(hwnd windows.HWND) (string, error) {
+	n, _, err := User32.NewProc("GetWindowTextLengthW").Call(uintptr(hwnd))
+	if n == 0 {
+		if err != windows.ERROR_SUCCESS {
+			return "", err
+		}
+		return "", nil
+	}
+
+	buf := make([]uint16, n+1)
+	r1, _, err := User32.NewProc("GetWindowTextW").Call(uintptr(hwnd), uintptr(unsafe.Pointer(&buf[0])), uintptr(len(buf)))
+	if r1 == 0 && err != windows.ERROR_SUCCESS {
+		return "", err
+	}
+	return windows.UTF16ToString(buf[:r1]), nil
+}
+
+// FindWindowByTitle returns the first top-level window whose title contains title, ignoring case.
+func FindWindowByTitle(title string) (windows.HWND, error) {
+	title = strings.ToLower(title)
+	var found windows.HWND
+	err := enumWindows(0, func(hwnd windows.HWND) bool {
+		text, err := GetWindowTextW(hwnd)
+		if err == nil && strings.Contains(strings.ToLower(text), title) {
+			found = hwnd
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return 0, err
+	}
+	if found == 0 {
+		return 0, ErrWindowNotFound
+	}
+	return found, nil
+}
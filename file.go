@@ -0,0 +1,329 @@
+package win32utils
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Access rights for CreateFileW.
+const (
+	GENERIC_READ    uint32 = 0x80000000
+	GENERIC_WRITE   uint32 = 0x40000000
+	GENERIC_EXECUTE uint32 = 0x20000000
+	GENERIC_ALL     uint32 = 0x10000000
+)
+
+// Share modes for CreateFileW.
+const (
+	FILE_SHARE_READ   uint32 = 0x00000001
+	FILE_SHARE_WRITE  uint32 = 0x00000002
+	FILE_SHARE_DELETE uint32 = 0x00000004
+)
+
+// Creation dispositions for CreateFileW.
+const (
+	CREATE_NEW        uint32 = 1
+	CREATE_ALWAYS     uint32 = 2
+	OPEN_EXISTING     uint32 = 3
+	OPEN_ALWAYS       uint32 = 4
+	TRUNCATE_EXISTING uint32 = 5
+)
+
+// File attributes for GetFileAttributesW, SetFileAttributesW and CreateFileW.
+const (
+	FILE_ATTRIBUTE_READONLY  uint32 = 0x00000001
+	FILE_ATTRIBUTE_HIDDEN    uint32 = 0x00000002
+	FILE_ATTRIBUTE_SYSTEM    uint32 = 0x00000004
+	FILE_ATTRIBUTE_DIRECTORY uint32 = 0x00000010
+	FILE_ATTRIBUTE_ARCHIVE   uint32 = 0x00000020
+	FILE_ATTRIBUTE_NORMAL    uint32 = 0x00000080
+	FILE_ATTRIBUTE_TEMPORARY uint32 = 0x00000100
+
+	INVALID_FILE_ATTRIBUTES uint32 = 0xFFFFFFFF
+)
+
+// Flags for CreateFileW.
+const (
+	FILE_FLAG_WRITE_THROUGH      uint32 = 0x80000000
+	FILE_FLAG_OVERLAPPED         uint32 = 0x40000000
+	FILE_FLAG_NO_BUFFERING       uint32 = 0x20000000
+	FILE_FLAG_RANDOM_ACCESS      uint32 = 0x10000000
+	FILE_FLAG_SEQUENTIAL_SCAN    uint32 = 0x08000000
+	FILE_FLAG_DELETE_ON_CLOSE    uint32 = 0x04000000
+	FILE_FLAG_BACKUP_SEMANTICS   uint32 = 0x02000000
+	FILE_FLAG_POSIX_SEMANTICS    uint32 = 0x01000000
+	FILE_FLAG_OPEN_REPARSE_POINT uint32 = 0x00200000
+)
+
+// CreateFileW creates or opens a file or I/O device.
+// flags combines FILE_ATTRIBUTE_* and FILE_FLAG_* values.
+func CreateFileW(path string, access, share, creation, flags uint32) (windows.Handle, error) {
+	pathPtr, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return windows.InvalidHandle, err
+	}
+
+	r1, _, err := Kernel32.NewProc("CreateFileW").Call(
+		uintptr(unsafe.Pointer(pathPtr)),
+		uintptr(access),
+		uintptr(share),
+		0,
+		uintptr(creation),
+		uintptr(flags),
+		0)
+	if windows.Handle(r1) == windows.InvalidHandle {
+		return windows.InvalidHandle, err
+	}
+	return windows.Handle(r1), nil
+}
+
+// ReadFile reads up to len(buf) bytes from handle and returns the number of bytes read.
+// A return of 0 with no error means end of file.
+func ReadFile(handle windows.Handle, buf []byte) (int, error) {
+	var p *byte
+	if len(buf) > 0 {
+		p = &buf[0]
+	}
+
+	var n uint32
+	r1, _, err := Kernel32.NewProc("ReadFile").Call(
+		uintptr(handle),
+		uintptr(unsafe.Pointer(p)),
+		uintptr(len(buf)),
+		uintptr(unsafe.Pointer(&n)),
+		0)
+	if r1 == 0 {
+		return int(n), err
+	}
+	return int(n), nil
+}
+
+// WriteFile writes buf to handle and returns the number of bytes written.
+func WriteFile(handle windows.Handle, buf []byte) (int, error) {
+	var p *byte
+	if len(buf) > 0 {
+		p = &buf[0]
+	}
+
+	var n uint32
+	r1, _, err := Kernel32.NewProc("WriteFile").Call(
+		uintptr(handle),
+		uintptr(unsafe.Pointer(p)),
+		uintptr(len(buf)),
+		uintptr(unsafe.Pointer(&n)),
+		0)
+	if r1 == 0 {
+		return int(n), err
+	}
+	return int(n), nil
+}
+
+// CloseHandle closes an open object handle.
+func CloseHandle(handle windows.Handle) error {
+	r1, _, err := Kernel32.NewProc("CloseHandle").Call(uintptr(handle))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// WIN32_FIND_DATAW contains information about a file found by FindFirstFileW or FindNextFileW.
+type WIN32_FIND_DATAW struct {
+	DwFileAttributes   uint32
+	FtCreationTime     windows.Filetime
+	FtLastAccessTime   windows.Filetime
+	FtLastWriteTime    windows.Filetime
+	NFileSizeHigh      uint32
+	NFileSizeLow       uint32
+	DwReserved0        uint32
+	DwReserved1        uint32
+	CFileName          [windows.MAX_PATH]uint16
+	CAlternateFileName [14]uint16
+}
+
+// FileName returns the name of the file.
+func (data *WIN32_FIND_DATAW) FileName() string {
+	return windows.UTF16ToString(data.CFileName[:])
+}
+
+// FileSize returns the size of the file in bytes.
+func (data *WIN32_FIND_DATAW) FileSize() uint64 {
+	return uint64(data.NFileSizeHigh)<<32 | uint64(data.NFileSizeLow)
+}
+
+// FindFirstFileW searches a directory for a file or subdirectory with a name that matches pattern,
+// which may contain the wildcards * and ?. The returned handle must be closed with FindClose.
+func FindFirstFileW(pattern string) (windows.Handle, WIN32_FIND_DATAW, error) {
+	var data WIN32_FIND_DATAW
+	patternPtr, err := windows.UTF16PtrFromString(pattern)
+	if err != nil {
+		return windows.InvalidHandle, data, err
+	}
+
+	r1, _, err := Kernel32.NewProc("FindFirstFileW").Call(
+		uintptr(unsafe.Pointer(patternPtr)),
+		uintptr(unsafe.Pointer(&data)))
+	if windows.Handle(r1) == windows.InvalidHandle {
+		return windows.InvalidHandle, data, err
+	}
+	return windows.Handle(r1), data, nil
+}
+
+// FindNextFileW continues a search started by FindFirstFileW.
+// It returns io.EOF when there are no more matching files.
+func FindNextFileW(handle windows.Handle) (WIN32_FIND_DATAW, error) {
+	var data WIN32_FIND_DATAW
+	r1, _, err := Kernel32.NewProc("FindNextFileW").Call(uintptr(handle), uintptr(unsafe.Pointer(&data)))
+	if r1 == 0 {
+		if err == windows.ERROR_NO_MORE_FILES {
+			return data, io.EOF
+		}
+		return data, err
+	}
+	return data, nil
+}
+
+// FindClose closes a search handle opened by FindFirstFileW.
+func FindClose(handle windows.Handle) error {
+	r1, _, err := Kernel32.NewProc("FindClose").Call(uintptr(handle))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// ListDirectory returns the entries of the directory path, without "." and "..".
+func ListDirectory(path string) ([]WIN32_FIND_DATAW, error) {
+	handle, data, err := FindFirstFileW(filepath.Join(path, "*"))
+	if err != nil {
+		return nil, err
+	}
+	defer FindClose(handle)
+
+	var entries []WIN32_FIND_DATAW
+	for {
+		if name := data.FileName(); name != "." && name != ".." {
+			entries = append(entries, data)
+		}
+
+		data, err = FindNextFileW(handle)
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return entries, err
+		}
+	}
+}
+
+// GetTempPathW returns the directory designated for temporary files, with a trailing backslash.
+func GetTempPathW() (string, error) {
+	buf := make([]uint16, windows.MAX_PATH+1)
+	for {
+		r1, _, err := Kernel32.NewProc("GetTempPathW").Call(uintptr(len(buf)), uintptr(unsafe.Pointer(&buf[0])))
+		if r1 == 0 {
+			return "", err
+		}
+		if int(r1) < len(buf) {
+			return windows.UTF16ToString(buf[:r1]), nil
+		}
+		buf = make([]uint16, r1)
+	}
+}
+
+// GetTempFileNameW creates a name for a temporary file in dir from prefix, of which only
+// the first three characters are used. If unique is 0 a unique name is generated and an empty
+// file with that name is created; otherwise the name is built from unique and no file is created.
+func GetTempFileNameW(dir, prefix string, unique uint32) (string, error) {
+	dirPtr, err := windows.UTF16PtrFromString(dir)
+	if err != nil {
+		return "", err
+	}
+	prefixPtr, err := windows.UTF16PtrFromString(prefix)
+	if err != nil {
+		return "", err
+	}
+
+	buf := make([]uint16, windows.MAX_PATH)
+	r1, _, err := Kernel32.NewProc("GetTempFileNameW").Call(
+		uintptr(unsafe.Pointer(dirPtr)),
+		uintptr(unsafe.Pointer(prefixPtr)),
+		uintptr(unique),
+		uintptr(unsafe.Pointer(&buf[0])))
+	if r1 == 0 {
+		return "", err
+	}
+	return windows.UTF16ToString(buf), nil
+}
+
+// CreateTempFile creates a uniquely named file in the temporary directory and opens it for reading and writing.
+// The caller is responsible for closing the handle and removing the file.
+func CreateTempFile(prefix string) (windows.Handle, string, error) {
+	dir, err := GetTempPathW()
+	if err != nil {
+		return windows.InvalidHandle, "", err
+	}
+	path, err := GetTempFileNameW(dir, prefix, 0)
+	if err != nil {
+		return windows.InvalidHandle, "", err
+	}
+
+	h, err := CreateFileW(path, GENERIC_READ|GENERIC_WRITE, FILE_SHARE_READ, TRUNCATE_EXISTING, 0)
+	if err != nil {
+		os.Remove(path)
+		return windows.InvalidHandle, "", err
+	}
+	return h, path, nil
+}
+
+// GetFileAttributesW retrieves the FILE_ATTRIBUTE_* bits of a file or directory.
+func GetFileAttributesW(path string) (uint32, error) {
+	pathPtr, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, err
+	}
+	r1, _, err := Kernel32.NewProc("GetFileAttributesW").Call(uintptr(unsafe.Pointer(pathPtr)))
+	if uint32(r1) == INVALID_FILE_ATTRIBUTES {
+		return 0, err
+	}
+	return uint32(r1), nil
+}
+
+// SetFileAttributesW sets the FILE_ATTRIBUTE_* bits of a file or directory.
+func SetFileAttributesW(path string, attributes uint32) error {
+	pathPtr, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return err
+	}
+	r1, _, err := Kernel32.NewProc("SetFileAttributesW").Call(uintptr(unsafe.Pointer(pathPtr)), uintptr(attributes))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// MakeFileHidden adds FILE_ATTRIBUTE_HIDDEN to path, keeping its other attributes.
+func MakeFileHidden(path string) error {
+	attrs, err := GetFileAttributesW(path)
+	if err != nil {
+		return err
+	}
+	return SetFileAttributesW(path, attrs|FILE_ATTRIBUTE_HIDDEN)
+}
+
+// MakeFileVisible removes FILE_ATTRIBUTE_HIDDEN from path, keeping its other attributes.
+func MakeFileVisible(path string) error {
+	attrs, err := GetFileAttributesW(path)
+	if err != nil {
+		return err
+	}
+	attrs &^= FILE_ATTRIBUTE_HIDDEN
+	if attrs == 0 {
+		attrs = FILE_ATTRIBUTE_NORMAL
+	}
+	return SetFileAttributesW(path, attrs)
+}
@@ -0,0 +1,111 @@
+package win32utils
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/sys/windows"
+)
+
+func TestCreateFileWReadWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hello.txt")
+
+	h, err := CreateFileW(path, GENERIC_WRITE, 0, CREATE_NEW, 0)
+	if err != nil {
+		t.Fatalf("CreateFileW(CREATE_NEW) error = %v", err)
+	}
+	n, err := WriteFile(h, []byte("hello"))
+	if err != nil || n != 5 {
+		t.Fatalf("WriteFile() = %d, %v, want 5, nil", n, err)
+	}
+	if err := CloseHandle(h); err != nil {
+		t.Fatalf("CloseHandle() error = %v", err)
+	}
+
+	h, err = CreateFileW(path, GENERIC_READ, FILE_SHARE_READ, OPEN_EXISTING, 0)
+	if err != nil {
+		t.Fatalf("CreateFileW(OPEN_EXISTING) error = %v", err)
+	}
+	defer CloseHandle(h)
+
+	buf := make([]byte, 16)
+	n, err = ReadFile(h, buf)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if got := string(buf[:n]); got != "hello" {
+		t.Errorf("ReadFile() = %q, want %q", got, "hello")
+	}
+}
+
+func TestListDirectory(t *testing.T) {
+	dir, err := windows.GetSystemDirectory()
+	if err != nil {
+		t.Fatalf("GetSystemDirectory() error = %v", err)
+	}
+
+	entries, err := ListDirectory(dir)
+	if err != nil {
+		t.Fatalf("ListDirectory(%q) error = %v", dir, err)
+	}
+	for i := range entries {
+		if strings.EqualFold(filepath.Ext(entries[i].FileName()), ".dll") {
+			return
+		}
+	}
+	t.Errorf("ListDirectory(%q) found no .dll among %d entries", dir, len(entries))
+}
+
+func TestCreateTempFile(t *testing.T) {
+	h, path, err := CreateTempFile("w32")
+	if err != nil {
+		t.Fatalf("CreateTempFile() error = %v", err)
+	}
+	defer os.Remove(path)
+
+	if _, err := WriteFile(h, []byte("temp")); err != nil {
+		t.Errorf("WriteFile() error = %v", err)
+	}
+	if err := CloseHandle(h); err != nil {
+		t.Fatalf("CloseHandle() error = %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("temp file %q does not exist: %v", path, err)
+	}
+	if info.Size() != 4 {
+		t.Errorf("temp file size = %d, want 4", info.Size())
+	}
+}
+
+func TestMakeFileHidden(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hidden.txt")
+	if err := os.WriteFile(path, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := MakeFileHidden(path); err != nil {
+		t.Fatalf("MakeFileHidden() error = %v", err)
+	}
+	attrs, err := GetFileAttributesW(path)
+	if err != nil {
+		t.Fatalf("GetFileAttributesW() error = %v", err)
+	}
+	if attrs&FILE_ATTRIBUTE_HIDDEN == 0 {
+		t.Errorf("attributes after MakeFileHidden = 0x%X, want FILE_ATTRIBUTE_HIDDEN set", attrs)
+	}
+
+	if err := MakeFileVisible(path); err != nil {
+		t.Fatalf("MakeFileVisible() error = %v", err)
+	}
+	attrs, err = GetFileAttributesW(path)
+	if err != nil {
+		t.Fatalf("GetFileAttributesW() error = %v", err)
+	}
+	if attrs&FILE_ATTRIBUTE_HIDDEN != 0 {
+		t.Errorf("attributes after MakeFileVisible = 0x%X, want FILE_ATTRIBUTE_HIDDEN cleared", attrs)
+	}
+}
@@ -0,0 +1,305 @@
+package win32utils
+
+import (
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Filters for ReadDirectoryChangesW.
+const (
+	FILE_NOTIFY_CHANGE_FILE_NAME   uint32 = 0x00000001
+	FILE_NOTIFY_CHANGE_DIR_NAME    uint32 = 0x00000002
+	FILE_NOTIFY_CHANGE_ATTRIBUTES  uint32 = 0x00000004
+	FILE_NOTIFY_CHANGE_SIZE        uint32 = 0x00000008
+	FILE_NOTIFY_CHANGE_LAST_WRITE  uint32 = 0x00000010
+	FILE_NOTIFY_CHANGE_LAST_ACCESS uint32 = 0x00000020
+	FILE_NOTIFY_CHANGE_CREATION    uint32 = 0x00000040
+	FILE_NOTIFY_CHANGE_SECURITY    uint32 = 0x00000100
+)
+
+// Actions reported in FileChangeEvent.Action.
+const (
+	FILE_ACTION_ADDED            uint32 = 0x00000001
+	FILE_ACTION_REMOVED          uint32 = 0x00000002
+	FILE_ACTION_MODIFIED         uint32 = 0x00000003
+	FILE_ACTION_RENAMED_OLD_NAME uint32 = 0x00000004
+	FILE_ACTION_RENAMED_NEW_NAME uint32 = 0x00000005
+)
+
+const FILE_LIST_DIRECTORY uint32 = 0x0001
+
+// FileChangeEvent describes a change to a file, relative to the watched directory.
+type FileChangeEvent struct {
+	Action   uint32
+	FileName string
+}
+
+// fileNotifyInformation mirrors the fixed part of FILE_NOTIFY_INFORMATION.
+type fileNotifyInformation struct {
+	NextEntryOffset uint32
+	Action          uint32
+	FileNameLength  uint32
+	FileName        uint16
+}
+
+// ReadDirectoryChangesW blocks until a change happens in dir, which must be a directory handle
+// opened with FILE_LIST_DIRECTORY and FILE_FLAG_BACKUP_SEMANTICS. buffer receives the raw records,
+// which are decoded into the returned events. No events and no error means the buffer overflowed
+// and the changes were lost.
+func ReadDirectoryChangesW(dir windows.Handle, watchSubtree bool, notifyFilter uint32, buffer []byte) ([]FileChangeEvent, error) {
+	if len(buffer) == 0 {
+		return nil, windows.ERROR_INVALID_PARAMETER
+	}
+
+	var subtree uintptr
+	if watchSubtree {
+		subtree = 1
+	}
+
+	var n uint32
+	r1, _, err := Kernel32.NewProc("ReadDirectoryChangesW").Call(
+		uintptr(dir),
+		uintptr(unsafe.Pointer(&buffer[0])),
+		uintptr(len(buffer)),
+		subtree,
+		uintptr(notifyFilter),
+		uintptr(unsafe.Pointer(&n)),
+		0,
+		0)
+	if r1 == 0 {
+		return nil, err
+	}
+	return parseFileNotifyInformation(buffer[:n]), nil
+}
+
+func parseFileNotifyInformation(buf []byte) []FileChangeEvent {
+	var events []FileChangeEvent
+	for offset := uint32(0); int(offset) < len(buf); {
+		info := (*fileNotifyInformation)(unsafe.Pointer(&buf[offset]))
+		name := unsafe.Slice(&info.FileName, info.FileNameLength/2)
+		events = append(events, FileChangeEvent{
+			Action:   info.Action,
+			FileName: windows.UTF16ToString(name),
+		})
+
+		if info.NextEntryOffset == 0 {
+			break
+		}
+		offset += info.NextEntryOffset
+	}
+	return events
+}
+
+// FileChangeWatcher watches a directory and reports changes to a callback.
+type FileChangeWatcher struct {
+	dir       windows.Handle
+	ioEvent   windows.Handle
+	stopEvent windows.Handle
+	recursive bool
+	filters   uint32
+	onChange  func(events []FileChangeEvent)
+	// onError, if set, is called with the error that stops the watcher, unless it was stopped by Close.
+	onError func(err error)
+
+	// buf and overlapped are written by the system asynchronously and must stay alive while a read is pending.
+	buf        []byte
+	overlapped windows.Overlapped
+	done       chan struct{}
+}
+
+// NewFileChangeWatcher starts watching path for the changes selected by filters.
+// onChange is called from the watcher's goroutine.
+func NewFileChangeWatcher(path string, recursive bool, filters uint32, onChange func(events []FileChangeEvent)) (*FileChangeWatcher, error) {
+	return newFileChangeWatcher(path, recursive, filters, onChange, nil)
+}
+
+func newFileChangeWatcher(path string, recursive bool, filters uint32, onChange func(events []FileChangeEvent), onError func(err error)) (*FileChangeWatcher, error) {
+	dir, err := CreateFileW(path, FILE_LIST_DIRECTORY,
+		FILE_SHARE_READ|FILE_SHARE_WRITE|FILE_SHARE_DELETE, OPEN_EXISTING,
+		FILE_FLAG_BACKUP_SEMANTICS|FILE_FLAG_OVERLAPPED)
+	if err != nil {
+		return nil, err
+	}
+
+	ioEvent, err := windows.CreateEvent(nil, 0, 0, nil)
+	if err != nil {
+		CloseHandle(dir)
+		return nil, err
+	}
+	stopEvent, err := windows.CreateEvent(nil, 1, 0, nil)
+	if err != nil {
+		CloseHandle(ioEvent)
+		CloseHandle(dir)
+		return nil, err
+	}
+
+	w := &FileChangeWatcher{
+		dir:       dir,
+		ioEvent:   ioEvent,
+		stopEvent: stopEvent,
+		recursive: recursive,
+		filters:   filters,
+		onChange:  onChange,
+		onError:   onError,
+		buf:       make([]byte, 64*1024),
+		done:      make(chan struct{}),
+	}
+	// The system only records changes once the first read has been issued, so issue it
+	// before returning; changes made after NewFileChangeWatcher returns are not lost.
+	if err := w.read(); err != nil {
+		CloseHandle(stopEvent)
+		CloseHandle(ioEvent)
+		CloseHandle(dir)
+		return nil, err
+	}
+	go w.run()
+	return w, nil
+}
+
+// read issues an overlapped ReadDirectoryChangesW that signals ioEvent when it completes.
+func (w *FileChangeWatcher) read() error {
+	var subtree uintptr
+	if w.recursive {
+		subtree = 1
+	}
+
+	w.overlapped = windows.Overlapped{HEvent: w.ioEvent}
+	r1, _, err := Kernel32.NewProc("ReadDirectoryChangesW").Call(
+		uintptr(w.dir),
+		uintptr(unsafe.Pointer(&w.buf[0])),
+		uintptr(len(w.buf)),
+		subtree,
+		uintptr(w.filters),
+		0,
+		uintptr(unsafe.Pointer(&w.overlapped)),
+		0)
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// run waits for the pending read to complete, reports its events and issues the next read.
+func (w *FileChangeWatcher) run() {
+	defer close(w.done)
+
+	for {
+		event, err := windows.WaitForMultipleObjects([]windows.Handle{w.ioEvent, w.stopEvent}, false, windows.INFINITE)
+		if err != nil || event != windows.WAIT_OBJECT_0 {
+			windows.CancelIoEx(w.dir, &w.overlapped)
+			var n uint32
+			windows.GetOverlappedResult(w.dir, &w.overlapped, &n, true)
+			if err != nil {
+				w.fail(err)
+			}
+			return
+		}
+
+		var n uint32
+		if err := windows.GetOverlappedResult(w.dir, &w.overlapped, &n, false); err != nil {
+			w.fail(err)
+			return
+		}
+		// n is 0 when the buffer overflowed; the changes are lost and watching continues.
+		if events := parseFileNotifyInformation(w.buf[:n]); len(events) > 0 {
+			w.onChange(events)
+		}
+
+		if err := w.read(); err != nil {
+			w.fail(err)
+			return
+		}
+	}
+}
+
+func (w *FileChangeWatcher) fail(err error) {
+	if w.onError != nil {
+		w.onError(err)
+	}
+}
+
+// Close stops the watcher, waits for its goroutine to exit and releases its handles.
+func (w *FileChangeWatcher) Close() error {
+	err := windows.SetEvent(w.stopEvent)
+	if err != nil {
+		return err
+	}
+	<-w.done
+
+	CloseHandle(w.ioEvent)
+	CloseHandle(w.stopEvent)
+	return CloseHandle(w.dir)
+}
+
+// DirectoryWatcher delivers the changes in a directory on a channel.
+type DirectoryWatcher struct {
+	watcher *FileChangeWatcher
+	events  chan FileChangeEvent
+	errors  chan error
+	closing chan struct{}
+	done    chan struct{}
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// WatchDirectory starts watching dir, and its subdirectories if recursive is set, for the
+// changes selected by filter, a combination of FILE_NOTIFY_CHANGE_ values.
+func WatchDirectory(dir string, recursive bool, filter uint32) (*DirectoryWatcher, error) {
+	d := &DirectoryWatcher{
+		events:  make(chan FileChangeEvent),
+		errors:  make(chan error, 1),
+		closing: make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	watcher, err := newFileChangeWatcher(dir, recursive, filter, d.send, func(err error) {
+		d.errors <- err
+	})
+	if err != nil {
+		return nil, err
+	}
+	d.watcher = watcher
+
+	go func() {
+		<-watcher.done
+		close(d.events)
+		close(d.errors)
+		close(d.done)
+	}()
+	return d, nil
+}
+
+func (d *DirectoryWatcher) send(events []FileChangeEvent) {
+	for _, ev := range events {
+		select {
+		case d.events <- ev:
+		case <-d.closing:
+			return
+		}
+	}
+}
+
+// Events returns the channel on which changes are delivered. The watcher waits until each event
+// is received, and changes that happen meanwhile are buffered by the system until its buffer overflows.
+// The channel is closed when the watcher stops.
+func (d *DirectoryWatcher) Events() <-chan FileChangeEvent {
+	return d.events
+}
+
+// Errors returns the channel that receives the error that stopped the watcher, if any.
+// It is closed when the watcher stops.
+func (d *DirectoryWatcher) Errors() <-chan error {
+	return d.errors
+}
+
+// Close stops the watcher and releases its handles. Events still pending are discarded.
+func (d *DirectoryWatcher) Close() error {
+	d.closeOnce.Do(func() {
+		close(d.closing)
+		d.closeErr = d.watcher.Close()
+		<-d.done
+	})
+	return d.closeErr
+}
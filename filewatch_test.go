@@ -0,0 +1,78 @@
+package win32utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileChangeWatcher(t *testing.T) {
+	dir := t.TempDir()
+	got := make(chan FileChangeEvent, 16)
+
+	w, err := NewFileChangeWatcher(dir, false, FILE_NOTIFY_CHANGE_FILE_NAME|FILE_NOTIFY_CHANGE_LAST_WRITE,
+		func(events []FileChangeEvent) {
+			for _, e := range events {
+				got <- e
+			}
+		})
+	if err != nil {
+		t.Fatalf("NewFileChangeWatcher() error = %v", err)
+	}
+	defer w.Close()
+
+	if err := os.WriteFile(filepath.Join(dir, "new.txt"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case e := <-got:
+		if e.FileName != "new.txt" {
+			t.Errorf("event FileName = %q, want %q", e.FileName, "new.txt")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no change event within 2 seconds")
+	}
+}
+
+func TestWatchDirectory(t *testing.T) {
+	dir := t.TempDir()
+	w, err := WatchDirectory(dir, true, FILE_NOTIFY_CHANGE_FILE_NAME)
+	if err != nil {
+		t.Fatalf("WatchDirectory() error = %v", err)
+	}
+	defer w.Close()
+
+	if err := os.WriteFile(filepath.Join(dir, "new.txt"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(filepath.Join(dir, "new.txt"), filepath.Join(dir, "renamed.txt")); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []FileChangeEvent{
+		{FILE_ACTION_ADDED, "new.txt"},
+		{FILE_ACTION_RENAMED_OLD_NAME, "new.txt"},
+		{FILE_ACTION_RENAMED_NEW_NAME, "renamed.txt"},
+	}
+	for _, ev := range want {
+		select {
+		case e := <-w.Events():
+			if e != ev {
+				t.Errorf("event = %+v, want %+v", e, ev)
+			}
+		case err := <-w.Errors():
+			t.Fatalf("Errors() = %v", err)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("no %+v event within 2 seconds", ev)
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+	if _, ok := <-w.Events(); ok {
+		t.Error("Events() not closed after Close")
+	}
+}
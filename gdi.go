@@ -0,0 +1,345 @@
+package win32utils
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// GetDC retrieves a handle to a device context for the client area of hwnd,
+// or for the entire screen if hwnd is 0. The DC must be released with ReleaseDC.
+func GetDC(hwnd windows.HWND) (windows.Handle, error) {
+	r1, _, err := User32.NewProc("GetDC").Call(uintptr(hwnd))
+	if r1 == 0 {
+		return 0, err
+	}
+	return windows.Handle(r1), nil
+}
+
+// GetWindowDC retrieves the device context for the entire window hwnd, including its frame.
+// It must be released with ReleaseDC.
+func GetWindowDC(hwnd windows.HWND) (windows.Handle, error) {
+	r1, _, err := User32.NewProc("GetWindowDC").Call(uintptr(hwnd))
+	if r1 == 0 {
+		return 0, err
+	}
+	return windows.Handle(r1), nil
+}
+
+// ReleaseDC releases a device context obtained with GetDC or GetWindowDC.
+func ReleaseDC(hwnd windows.HWND, hdc windows.Handle) error {
+	r1, _, _ := User32.NewProc("ReleaseDC").Call(uintptr(hwnd), uintptr(hdc))
+	if r1 == 0 {
+		return windows.ERROR_INVALID_HANDLE
+	}
+	return nil
+}
+
+// Device capability indices for GetDeviceCaps.
+const (
+	HORZRES        int32 = 8
+	VERTRES        int32 = 10
+	BITSPIXEL      int32 = 12
+	PLANES         int32 = 14
+	LOGPIXELSX     int32 = 88
+	LOGPIXELSY     int32 = 90
+	DESKTOPVERTRES int32 = 117
+	DESKTOPHORZRES int32 = 118
+)
+
+// GetDeviceCaps retrieves device-specific information for the specified device context.
+func GetDeviceCaps(hdc windows.Handle, index int32) int32 {
+	r1, _, _ := Gdi32.NewProc("GetDeviceCaps").Call(uintptr(hdc), uintptr(index))
+	return int32(r1)
+}
+
+// GetScreenResolution returns the physical resolution of the primary display,
+// independent of DPI virtualization.
+func GetScreenResolution() (width, height int32, err error) {
+	hdc, err := GetDC(0)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer ReleaseDC(0, hdc)
+
+	return GetDeviceCaps(hdc, DESKTOPHORZRES), GetDeviceCaps(hdc, DESKTOPVERTRES), nil
+}
+
+// GetScreenDPI returns the logical DPI of the screen along both axes.
+func GetScreenDPI() (dpiX, dpiY int32, err error) {
+	hdc, err := GetDC(0)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer ReleaseDC(0, hdc)
+
+	return GetDeviceCaps(hdc, LOGPIXELSX), GetDeviceCaps(hdc, LOGPIXELSY), nil
+}
+
+// Values for BLENDFUNCTION.
+const (
+	AC_SRC_OVER  byte = 0x00
+	AC_SRC_ALPHA byte = 0x01
+)
+
+// BLENDFUNCTION controls blending by specifying the blending functions for source and destination bitmaps.
+type BLENDFUNCTION struct {
+	BlendOp             byte
+	BlendFlags          byte
+	SourceConstantAlpha byte
+	AlphaFormat         byte
+}
+
+// AlphaBlend displays bitmaps that have transparent or semitransparent pixels.
+// Check https://learn.microsoft.com/en-us/windows/win32/api/wingdi/nf-wingdi-alphablend for more detail.
+func AlphaBlend(dst windows.Handle, dstX, dstY, dstW, dstH int32,
+	src windows.Handle, srcX, srcY, srcW, srcH int32, bf BLENDFUNCTION) error {
+	// BLENDFUNCTION is passed by value, packed into a single DWORD.
+	blend := uintptr(bf.BlendOp) | uintptr(bf.BlendFlags)<<8 |
+		uintptr(bf.SourceConstantAlpha)<<16 | uintptr(bf.AlphaFormat)<<24
+	r1, _, err := Msimg32.NewProc("AlphaBlend").Call(
+		uintptr(dst),
+		uintptr(dstX),
+		uintptr(dstY),
+		uintptr(dstW),
+		uintptr(dstH),
+		uintptr(src),
+		uintptr(srcX),
+		uintptr(srcY),
+		uintptr(srcW),
+		uintptr(srcH),
+		blend)
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// Raster operation for BitBlt.
+const (
+	SRCCOPY    uint32 = 0x00CC0020
+	CAPTUREBLT uint32 = 0x40000000
+)
+
+// Compression and color table usage values for BITMAPINFOHEADER and GetDIBits.
+const (
+	BI_RGB         uint32 = 0
+	BI_BITFIELDS   uint32 = 3
+	DIB_RGB_COLORS uint32 = 0
+)
+
+// BITMAPINFOHEADER contains information about the dimensions and color format of a device-independent bitmap.
+// A negative BiHeight describes a top-down bitmap.
+type BITMAPINFOHEADER struct {
+	BiSize          uint32
+	BiWidth         int32
+	BiHeight        int32
+	BiPlanes        uint16
+	BiBitCount      uint16
+	BiCompression   uint32
+	BiSizeImage     uint32
+	BiXPelsPerMeter int32
+	BiYPelsPerMeter int32
+	BiClrUsed       uint32
+	BiClrImportant  uint32
+}
+
+// BITMAPINFO defines the dimensions and color information for a device-independent bitmap.
+type BITMAPINFO struct {
+	BmiHeader BITMAPINFOHEADER
+	BmiColors [1]uint32
+}
+
+// CreateCompatibleDC creates a memory device context compatible with hdc, or with the screen if hdc is 0.
+// The DC must be deleted with DeleteDC.
+func CreateCompatibleDC(hdc windows.Handle) (windows.Handle, error) {
+	r1, _, err := Gdi32.NewProc("CreateCompatibleDC").Call(uintptr(hdc))
+	if r1 == 0 {
+		return 0, err
+	}
+	return windows.Handle(r1), nil
+}
+
+// DeleteDC deletes a device context created with CreateCompatibleDC.
+func DeleteDC(hdc windows.Handle) error {
+	r1, _, err := Gdi32.NewProc("DeleteDC").Call(uintptr(hdc))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// CreateCompatibleBitmap creates a bitmap compatible with the device associated with hdc.
+// The bitmap must be deleted with DeleteObject.
+func CreateCompatibleBitmap(hdc windows.Handle, width, height int32) (windows.Handle, error) {
+	r1, _, err := Gdi32.NewProc("CreateCompatibleBitmap").Call(uintptr(hdc), uintptr(width), uintptr(height))
+	if r1 == 0 {
+		return 0, err
+	}
+	return windows.Handle(r1), nil
+}
+
+// SelectObject selects an object into hdc and returns the previously selected object of the same type.
+func SelectObject(hdc windows.Handle, obj windows.Handle) (windows.Handle, error) {
+	r1, _, err := Gdi32.NewProc("SelectObject").Call(uintptr(hdc), uintptr(obj))
+	if r1 == 0 {
+		return 0, err
+	}
+	return windows.Handle(r1), nil
+}
+
+// DeleteObject deletes a logical pen, brush, font, bitmap, region, or palette.
+func DeleteObject(obj windows.Handle) error {
+	r1, _, err := Gdi32.NewProc("DeleteObject").Call(uintptr(obj))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// BitBlt performs a bit-block transfer of color data from src to dst.
+func BitBlt(dst windows.Handle, x, y, width, height int32, src windows.Handle, srcX, srcY int32, rop uint32) error {
+	r1, _, err := Gdi32.NewProc("BitBlt").Call(
+		uintptr(dst),
+		uintptr(x),
+		uintptr(y),
+		uintptr(width),
+		uintptr(height),
+		uintptr(src),
+		uintptr(srcX),
+		uintptr(srcY),
+		uintptr(rop))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// GetDIBits copies scanLines rows of hBitmap, starting at startScan, into bits using the format described by bi.
+// It returns the number of scan lines copied.
+func GetDIBits(hdc, hBitmap windows.Handle, startScan, scanLines uint32, bits []byte, bi *BITMAPINFO, usage uint32) (int32, error) {
+	var p *byte
+	if len(bits) > 0 {
+		p = &bits[0]
+	}
+
+	r1, _, err := Gdi32.NewProc("GetDIBits").Call(
+		uintptr(hdc),
+		uintptr(hBitmap),
+		uintptr(startScan),
+		uintptr(scanLines),
+		uintptr(unsafe.Pointer(p)),
+		uintptr(unsafe.Pointer(bi)),
+		uintptr(usage))
+	if r1 == 0 {
+		return 0, err
+	}
+	return int32(r1), nil
+}
+
+// CaptureScreen copies a region of the screen and returns its pixels as top-down 32-bit BGRA rows.
+func CaptureScreen(x, y, width, height int32) ([]byte, int, int, error) {
+	if width <= 0 || height <= 0 {
+		return nil, 0, 0, windows.ERROR_INVALID_PARAMETER
+	}
+	screen, err := GetDC(0)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	defer ReleaseDC(0, screen)
+
+	pixels, err := captureDC(screen, x, y, width, height)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	return pixels, int(width), int(height), nil
+}
+
+// CaptureScreenRect copies the screen area from (left, top) to (right, bottom) and returns
+// its pixels as top-down RGBA rows.
+func CaptureScreenRect(left, top, right, bottom int32) ([]byte, error) {
+	if right <= left || bottom <= top {
+		return nil, windows.ERROR_INVALID_PARAMETER
+	}
+	pixels, _, _, err := CaptureScreen(left, top, right-left, bottom-top)
+	if err != nil {
+		return nil, err
+	}
+	bgraToRGBA(pixels)
+	return pixels, nil
+}
+
+// CaptureWindowBitmap copies the window hwnd, including its frame, and returns its pixels as
+// top-down RGBA rows. Parts of the window that are covered or off-screen may not be drawn.
+func CaptureWindowBitmap(hwnd windows.HWND) (width, height int, rgba []byte, err error) {
+	rect, err := GetWindowRect(hwnd)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	w, h := rect.Right-rect.Left, rect.Bottom-rect.Top
+	if w <= 0 || h <= 0 {
+		return 0, 0, nil, windows.ERROR_INVALID_PARAMETER
+	}
+
+	hdc, err := GetWindowDC(hwnd)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	defer ReleaseDC(hwnd, hdc)
+
+	pixels, err := captureDC(hdc, 0, 0, w, h)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	bgraToRGBA(pixels)
+	return int(w), int(h), pixels, nil
+}
+
+// bgraToRGBA swaps the blue and red channels of 32-bit pixels in place. The alpha channel of
+// captured pixels is undefined, so it is set to opaque.
+func bgraToRGBA(pixels []byte) {
+	for i := 0; i+3 < len(pixels); i += 4 {
+		pixels[i], pixels[i+2], pixels[i+3] = pixels[i+2], pixels[i], 0xFF
+	}
+}
+
+// captureDC copies a region of src into a memory bitmap and returns it as top-down 32-bit BGRA rows.
+func captureDC(src windows.Handle, x, y, width, height int32) ([]byte, error) {
+	mem, err := CreateCompatibleDC(src)
+	if err != nil {
+		return nil, err
+	}
+	defer DeleteDC(mem)
+
+	bitmap, err := CreateCompatibleBitmap(src, width, height)
+	if err != nil {
+		return nil, err
+	}
+	defer DeleteObject(bitmap)
+
+	old, err := SelectObject(mem, bitmap)
+	if err != nil {
+		return nil, err
+	}
+	err = BitBlt(mem, 0, 0, width, height, src, x, y, SRCCOPY|CAPTUREBLT)
+	SelectObject(mem, old)
+	if err != nil {
+		return nil, err
+	}
+
+	bi := BITMAPINFO{BmiHeader: BITMAPINFOHEADER{
+		BiWidth:       width,
+		BiHeight:      -height,
+		BiPlanes:      1,
+		BiBitCount:    32,
+		BiCompression: BI_RGB,
+	}}
+	bi.BmiHeader.BiSize = uint32(unsafe.Sizeof(bi.BmiHeader))
+
+	pixels := make([]byte, int(width)*int(height)*4)
+	_, err = GetDIBits(mem, bitmap, 0, uint32(height), pixels, &bi, DIB_RGB_COLORS)
+	if err != nil {
+		return nil, err
+	}
+	return pixels, nil
+}
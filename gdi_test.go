@@ -0,0 +1,175 @@
+package win32utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"golang.org/x/sys/windows"
+)
+
+func TestGetScreenResolution(t *testing.T) {
+	width, height, err := GetScreenResolution()
+	if err != nil {
+		t.Fatalf("GetScreenResolution() error = %v", err)
+	}
+	if width <= 0 || height <= 0 {
+		t.Errorf("GetScreenResolution() = %dx%d, want positive", width, height)
+	}
+}
+
+func TestAlphaBlend(t *testing.T) {
+	screen, err := GetDC(0)
+	if err != nil {
+		t.Fatalf("GetDC() error = %v", err)
+	}
+	defer ReleaseDC(0, screen)
+
+	// Bitmaps compatible with a memory DC would be monochrome, so create them from the screen DC.
+	var dcs [2]windows.Handle
+	for i := range dcs {
+		dc, err := CreateCompatibleDC(screen)
+		if err != nil {
+			t.Fatalf("CreateCompatibleDC() error = %v", err)
+		}
+		defer DeleteDC(dc)
+		bmp, err := CreateCompatibleBitmap(screen, 16, 16)
+		if err != nil {
+			t.Fatalf("CreateCompatibleBitmap() error = %v", err)
+		}
+		defer DeleteObject(bmp)
+		old, err := SelectObject(dc, bmp)
+		if err != nil {
+			t.Fatalf("SelectObject() error = %v", err)
+		}
+		defer SelectObject(dc, old)
+		dcs[i] = dc
+	}
+
+	bf := BLENDFUNCTION{BlendOp: AC_SRC_OVER, SourceConstantAlpha: 128}
+	if err := AlphaBlend(dcs[0], 0, 0, 16, 16, dcs[1], 0, 0, 16, 16, bf); err != nil {
+		t.Errorf("AlphaBlend() error = %v", err)
+	}
+}
+
+func TestCaptureScreen(t *testing.T) {
+	pixels, width, height, err := CaptureScreen(0, 0, 100, 100)
+	if err != nil {
+		t.Fatalf("CaptureScreen() error = %v", err)
+	}
+	if width != 100 || height != 100 {
+		t.Errorf("CaptureScreen() size = %dx%d, want 100x100", width, height)
+	}
+	if len(pixels) != 100*100*4 {
+		t.Errorf("len(pixels) = %d, want %d", len(pixels), 100*100*4)
+	}
+}
+
+func TestCaptureScreenInvalidSize(t *testing.T) {
+	sizes := []struct{ width, height int32 }{{0, 10}, {10, 0}, {-1, 10}, {10, -1}}
+	for _, sz := range sizes {
+		if _, _, _, err := CaptureScreen(0, 0, sz.width, sz.height); err != windows.ERROR_INVALID_PARAMETER {
+			t.Errorf("CaptureScreen(%dx%d) error = %v, want %v", sz.width, sz.height, err, windows.ERROR_INVALID_PARAMETER)
+		}
+	}
+}
+
+func TestCaptureWindowBitmap(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	hwnd, err := CreateWindowExW(0, "STATIC", "", WS_POPUP|WS_VISIBLE, 0, 0, 40, 30, 0, 0, moduleHandle(), 0)
+	if err != nil {
+		t.Fatalf("CreateWindowExW() error = %v", err)
+	}
+	defer DestroyWindow(hwnd)
+
+	width, height, rgba, err := CaptureWindowBitmap(hwnd)
+	if err != nil {
+		t.Fatalf("CaptureWindowBitmap() error = %v", err)
+	}
+	if width != 40 || height != 30 || len(rgba) != 40*30*4 {
+		t.Errorf("CaptureWindowBitmap() = %dx%d with %d bytes, want 40x30 with %d", width, height, len(rgba), 40*30*4)
+	}
+
+	pixels, err := CaptureScreenRect(10, 10, 20, 15)
+	if err != nil {
+		t.Fatalf("CaptureScreenRect() error = %v", err)
+	}
+	if len(pixels) != 10*5*4 {
+		t.Errorf("len(CaptureScreenRect()) = %d, want %d", len(pixels), 10*5*4)
+	}
+	if _, err := CaptureScreenRect(10, 10, 10, 20); err == nil {
+		t.Error("CaptureScreenRect() of an empty rect succeeded")
+	}
+}
+
+func TestBGRAToRGBA(t *testing.T) {
+	pixels := []byte{1, 2, 3, 0, 4, 5, 6, 0}
+	bgraToRGBA(pixels)
+	if want := []byte{3, 2, 1, 0xFF, 6, 5, 4, 0xFF}; !bytes.Equal(pixels, want) {
+		t.Errorf("bgraToRGBA() = %v, want %v", pixels, want)
+	}
+}
+
+func TestDrawIcon(t *testing.T) {
+	const IDI_APPLICATION = 32512
+	icon, _, err := User32.NewProc("LoadIconW").Call(0, IDI_APPLICATION)
+	if icon == 0 {
+		t.Fatalf("LoadIconW() error = %v", err)
+	}
+
+	hdc, err := CreateCompatibleDC(0)
+	if err != nil {
+		t.Fatalf("CreateCompatibleDC() error = %v", err)
+	}
+	defer DeleteDC(hdc)
+	screen, err := GetDC(0)
+	if err != nil {
+		t.Fatalf("GetDC() error = %v", err)
+	}
+	bitmap, err := CreateCompatibleBitmap(screen, 64, 64)
+	ReleaseDC(0, screen)
+	if err != nil {
+		t.Fatalf("CreateCompatibleBitmap() error = %v", err)
+	}
+	defer DeleteObject(bitmap)
+	old, _ := SelectObject(hdc, bitmap)
+	defer SelectObject(hdc, old)
+
+	if err := DrawIcon(hdc, 0, 0, windows.Handle(icon)); err != nil {
+		t.Errorf("DrawIcon() error = %v", err)
+	}
+}
+
+func TestLoadBitmapFromFile(t *testing.T) {
+	// A 2x1 24-bit bitmap: file header, BITMAPINFOHEADER and one row padded to 4 bytes.
+	bmp := make([]byte, 14+40+8)
+	copy(bmp, "BM")
+	binary.LittleEndian.PutUint32(bmp[2:], uint32(len(bmp)))
+	binary.LittleEndian.PutUint32(bmp[10:], 14+40)
+	binary.LittleEndian.PutUint32(bmp[14:], 40)
+	binary.LittleEndian.PutUint32(bmp[18:], 2)
+	binary.LittleEndian.PutUint32(bmp[22:], 1)
+	binary.LittleEndian.PutUint16(bmp[26:], 1)
+	binary.LittleEndian.PutUint16(bmp[28:], 24)
+	path := filepath.Join(t.TempDir(), "test.bmp")
+	if err := os.WriteFile(path, bmp, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	bitmap, err := LoadBitmapFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadBitmapFromFile() error = %v", err)
+	}
+	if err := DeleteObject(bitmap); err != nil {
+		t.Errorf("DeleteObject() error = %v", err)
+	}
+
+	if _, err := LoadIconFromFile(filepath.Join(t.TempDir(), "missing.ico")); err == nil {
+		t.Error("LoadIconFromFile() on a missing file succeeded")
+	}
+}
@@ -0,0 +1,241 @@
+package win32utils
+
+import (
+	"runtime"
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Hook types for SetWindowsHookExW.
+const (
+	WH_KEYBOARD_LL int32 = 13
+	WH_MOUSE_LL    int32 = 14
+)
+
+// HC_ACTION is the hook code for which low-level hook procedures must process the message.
+const HC_ACTION int32 = 0
+
+// Low-level keyboard input event flags of KBDLLHOOKSTRUCT.
+const (
+	LLKHF_EXTENDED          uint32 = 0x01
+	LLKHF_LOWER_IL_INJECTED uint32 = 0x02
+	LLKHF_INJECTED          uint32 = 0x10
+	LLKHF_ALTDOWN           uint32 = 0x20
+	LLKHF_UP                uint32 = 0x80
+)
+
+// Low-level mouse input event flags of MSLLHOOKSTRUCT.
+const (
+	LLMHF_INJECTED          uint32 = 0x01
+	LLMHF_LOWER_IL_INJECTED uint32 = 0x02
+)
+
+// MSLLHOOKSTRUCT describes a low-level mouse input event.
+type MSLLHOOKSTRUCT struct {
+	Pt        POINT
+	MouseData uint32
+	Flags     uint32
+	Time      uint32
+	ExtraInfo uintptr
+}
+
+// KBDLLHOOKSTRUCT describes a low-level keyboard input event.
+type KBDLLHOOKSTRUCT struct {
+	VkCode    uint32
+	ScanCode  uint32
+	Flags     uint32
+	Time      uint32
+	ExtraInfo uintptr
+}
+
+// lowLevelHook is a low-level hook installed on its own thread, which runs the message loop
+// that the system needs to call it.
+type lowLevelHook struct {
+	tid   uint32
+	hhook windows.Handle
+	// handle processes an HC_ACTION event and reports whether to suppress it.
+	handle func(wParam, lParam uintptr) bool
+	done   chan struct{}
+
+	closeOnce sync.Once
+}
+
+var (
+	lowLevelHooksMu sync.Mutex
+	// lowLevelHooks maps the installing thread to its hook, since the hook procedure is not
+	// given any context.
+	lowLevelHooks = make(map[uint32]*lowLevelHook)
+)
+
+// lowLevelHookCallback is shared by all low-level hooks; see globalWndProcCallback.
+var lowLevelHookCallback = windows.NewCallback(func(nCode int32, wParam, lParam uintptr) uintptr {
+	if nCode == HC_ACTION {
+		lowLevelHooksMu.Lock()
+		h := lowLevelHooks[GetCurrentThreadId()]
+		lowLevelHooksMu.Unlock()
+		if h != nil && h.handle(wParam, lParam) {
+			return 1
+		}
+	}
+	r1, _, _ := User32.NewProc("CallNextHookEx").Call(0, uintptr(nCode), wParam, lParam)
+	return r1
+})
+
+// startLowLevelHook installs a hook of type idHook on a new thread and runs its message loop
+// until close is called.
+func startLowLevelHook(idHook int32, handle func(wParam, lParam uintptr) bool) (*lowLevelHook, error) {
+	h := &lowLevelHook{handle: handle, done: make(chan struct{})}
+	errc := make(chan error, 1)
+
+	go func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		defer close(h.done)
+
+		h.tid = GetCurrentThreadId()
+		lowLevelHooksMu.Lock()
+		lowLevelHooks[h.tid] = h
+		lowLevelHooksMu.Unlock()
+		defer func() {
+			lowLevelHooksMu.Lock()
+			delete(lowLevelHooks, h.tid)
+			lowLevelHooksMu.Unlock()
+		}()
+
+		// Create the message queue before close can post WM_QUIT to it.
+		var msg MSG
+		PeekMessageW(&msg, 0, 0, 0, PM_NOREMOVE)
+
+		r1, _, err := User32.NewProc("SetWindowsHookExW").Call(uintptr(idHook), lowLevelHookCallback, uintptr(moduleHandle()), 0)
+		if r1 == 0 {
+			errc <- err
+			return
+		}
+		h.hhook = windows.Handle(r1)
+		errc <- nil
+
+		_, _ = MessageLoop()
+		User32.NewProc("UnhookWindowsHookEx").Call(uintptr(h.hhook))
+	}()
+
+	if err := <-errc; err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// close removes the hook and waits for its thread to exit.
+func (h *lowLevelHook) close() error {
+	var err error
+	h.closeOnce.Do(func() {
+		r1, _, e := User32.NewProc("PostThreadMessageW").Call(uintptr(h.tid), uintptr(WM_QUIT), 0, 0)
+		if r1 == 0 {
+			err = e
+			return
+		}
+		<-h.done
+	})
+	return err
+}
+
+// KeyboardHook is a global low-level keyboard hook.
+type KeyboardHook struct {
+	hook *lowLevelHook
+}
+
+// NewKeyboardHook installs a WH_KEYBOARD_LL hook that calls callback with the fields of the
+// KBDLLHOOKSTRUCT of every keystroke in the session. Returning true suppresses the keystroke.
+//
+// callback runs on the dedicated hook thread and must return quickly: the system skips
+// hooks that exceed the LowLevelHooksTimeout, and input is blocked until it returns.
+func NewKeyboardHook(callback func(vk uint32, scanCode uint32, flags uint32, extra uintptr) bool) (*KeyboardHook, error) {
+	hook, err := startLowLevelHook(WH_KEYBOARD_LL, func(wParam, lParam uintptr) bool {
+		info := (*KBDLLHOOKSTRUCT)(unsafe.Pointer(lParam))
+		return callback(info.VkCode, info.ScanCode, info.Flags, info.ExtraInfo)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &KeyboardHook{hook: hook}, nil
+}
+
+// Close removes the hook with UnhookWindowsHookEx and waits for the hook thread to exit.
+func (h *KeyboardHook) Close() error {
+	return h.hook.close()
+}
+
+// MouseEvent is a low-level mouse input event. Message is one of WM_MOUSEMOVE, WM_LBUTTONDOWN and the
+// other mouse messages; Pt is in per-monitor-aware screen coordinates. For WM_MOUSEWHEEL and
+// WM_MOUSEHWHEEL the high word of MouseData is the wheel delta, and for WM_XBUTTONDOWN and
+// WM_XBUTTONUP it identifies the button.
+type MouseEvent struct {
+	Message   uint32
+	Pt        POINT
+	MouseData uint32
+	Flags     uint32
+	Time      uint32
+	ExtraInfo uintptr
+}
+
+// MouseHook is a global low-level mouse hook.
+type MouseHook struct {
+	hook   *lowLevelHook
+	events chan MouseEvent
+
+	closeEvents sync.Once
+}
+
+// mouseHookBuffer is the capacity of MouseHook.Events. The hook thread must not block, so
+// events that do not fit are dropped.
+const mouseHookBuffer = 256
+
+// NewMouseHook installs a WH_MOUSE_LL hook for every mouse event in the session. Each event is
+// passed to callback, if it is not nil, and then sent to Events unless it was suppressed.
+// Returning true from callback suppresses the event.
+//
+// callback runs on the dedicated hook thread and must return quickly: the system skips
+// hooks that exceed the LowLevelHooksTimeout, and input is blocked until it returns.
+func NewMouseHook(callback func(ev MouseEvent) bool) (*MouseHook, error) {
+	h := &MouseHook{events: make(chan MouseEvent, mouseHookBuffer)}
+	hook, err := startLowLevelHook(WH_MOUSE_LL, func(wParam, lParam uintptr) bool {
+		info := (*MSLLHOOKSTRUCT)(unsafe.Pointer(lParam))
+		ev := MouseEvent{
+			Message:   uint32(wParam),
+			Pt:        info.Pt,
+			MouseData: info.MouseData,
+			Flags:     info.Flags,
+			Time:      info.Time,
+			ExtraInfo: info.ExtraInfo,
+		}
+		if callback != nil && callback(ev) {
+			return true
+		}
+		select {
+		case h.events <- ev:
+		default:
+		}
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+	h.hook = hook
+	return h, nil
+}
+
+// Events returns the channel on which events that were not suppressed are delivered.
+// Events are dropped while the channel is full. It is closed by Close.
+func (h *MouseHook) Events() <-chan MouseEvent {
+	return h.events
+}
+
+// Close removes the hook with UnhookWindowsHookEx, waits for the hook thread to exit and closes Events.
+func (h *MouseHook) Close() error {
+	err := h.hook.close()
+	if err == nil {
+		h.closeEvents.Do(func() { close(h.events) })
+	}
+	return err
+}
@@ -0,0 +1,91 @@
+package win32utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestKeyboardHook(t *testing.T) {
+	const KEYEVENTF_KEYUP = 0x0002
+
+	got := make(chan uint32, 2)
+	hook, err := NewKeyboardHook(func(vk, scanCode, flags uint32, extra uintptr) bool {
+		if vk != VK_F24 {
+			return false
+		}
+		select {
+		case got <- flags:
+		default:
+		}
+		return true
+	})
+	if err != nil {
+		t.Fatalf("NewKeyboardHook() error = %v", err)
+	}
+	defer hook.Close()
+
+	keybdEvent := User32.NewProc("keybd_event")
+	keybdEvent.Call(uintptr(VK_F24), 0, 0, 0)
+	keybdEvent.Call(uintptr(VK_F24), 0, KEYEVENTF_KEYUP, 0)
+
+	select {
+	case flags := <-got:
+		if flags&LLKHF_INJECTED == 0 {
+			t.Errorf("KeyboardHook flags = %#x, want LLKHF_INJECTED set", flags)
+		}
+	case <-time.After(2 * time.Second):
+		t.Skip("no keyboard input delivered; the test needs an interactive desktop")
+	}
+
+	if err := hook.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+	if err := hook.Close(); err != nil {
+		t.Errorf("second Close() error = %v", err)
+	}
+}
+
+func TestMouseHook(t *testing.T) {
+	const MOUSEEVENTF_MOVE = 0x0001
+
+	hook, err := NewMouseHook(func(ev MouseEvent) bool {
+		// Keep the real cursor where it is.
+		return ev.Message == WM_MOUSEMOVE && ev.Flags&LLMHF_INJECTED != 0 && ev.ExtraInfo == 0x5754
+	})
+	if err != nil {
+		t.Fatalf("NewMouseHook() error = %v", err)
+	}
+	defer hook.Close()
+
+	// A zero move, which is suppressed, and a lone middle button release, which is delivered.
+	const MOUSEEVENTF_MIDDLEUP = 0x0040
+	mouseEvent := User32.NewProc("mouse_event")
+	mouseEvent.Call(MOUSEEVENTF_MOVE, 0, 0, 0, 0x5754)
+	mouseEvent.Call(MOUSEEVENTF_MIDDLEUP, 0, 0, 0, 0x5754)
+
+	// Real mouse input can arrive meanwhile, so only the injected events are checked.
+	// The first of them must be the button release, since the move was suppressed.
+	timeout := time.After(2 * time.Second)
+wait:
+	for {
+		select {
+		case ev := <-hook.Events():
+			if ev.Flags&LLMHF_INJECTED == 0 || ev.ExtraInfo != 0x5754 {
+				continue
+			}
+			if ev.Message != WM_MBUTTONUP {
+				t.Errorf("MouseHook event = %+v, want the injected WM_MBUTTONUP", ev)
+			}
+			break wait
+		case <-timeout:
+			t.Skip("no mouse input delivered; the test needs an interactive desktop")
+		}
+	}
+
+	if err := hook.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+	if _, ok := <-hook.Events(); ok {
+		t.Error("Events() not closed after Close")
+	}
+}
@@ -0,0 +1,189 @@
+package win32utils
+
+import (
+	"runtime"
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Modifiers for RegisterHotKey.
+const (
+	MOD_ALT      uint32 = 0x0001
+	MOD_CONTROL  uint32 = 0x0002
+	MOD_SHIFT    uint32 = 0x0004
+	MOD_WIN      uint32 = 0x0008
+	MOD_NOREPEAT uint32 = 0x4000
+)
+
+// RegisterHotKey registers a system-wide hotkey for the calling thread. WM_HOTKEY is posted
+// to the thread's message queue with wParam set to id, so the calling goroutine should be
+// locked to its OS thread and run a message loop. HotkeyListener does all of this.
+func RegisterHotKey(id int, modifiers uint32, vk uint32) error {
+	return registerHotKey(0, id, modifiers, vk)
+}
+
+// UnregisterHotKey frees a hotkey registered by the calling thread with RegisterHotKey.
+func UnregisterHotKey(id int) error {
+	return unregisterHotKey(0, id)
+}
+
+func registerHotKey(hwnd windows.HWND, id int, modifiers uint32, vk uint32) error {
+	r1, _, err := User32.NewProc("RegisterHotKey").Call(uintptr(hwnd), uintptr(id), uintptr(modifiers), uintptr(vk))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+func unregisterHotKey(hwnd windows.HWND, id int) error {
+	r1, _, err := User32.NewProc("UnregisterHotKey").Call(uintptr(hwnd), uintptr(id))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// HotkeyEvent describes a pressed hotkey.
+type HotkeyEvent struct {
+	ID        int
+	Modifiers uint32
+	VK        uint32
+}
+
+const hotkeyListenerClass = "win32utils_hotkey_listener"
+
+// Private messages of the listener window. lParam points to a hotkeyRequest.
+const (
+	wmHotkeyRegister   = WM_APP + 1
+	wmHotkeyUnregister = WM_APP + 2
+)
+
+type hotkeyRequest struct {
+	id        int
+	modifiers uint32
+	vk        uint32
+	err       error
+}
+
+// HotkeyListener owns a message-only window on its own thread and delivers the hotkeys
+// registered through it on a channel.
+type HotkeyListener struct {
+	hwnd    windows.HWND
+	events  chan HotkeyEvent
+	closing chan struct{}
+	done    chan struct{}
+
+	// ids is only used on the listener thread.
+	ids map[int]struct{}
+
+	closeOnce sync.Once
+}
+
+// NewHotkeyListener starts a listener thread with its own message-only window.
+func NewHotkeyListener() (*HotkeyListener, error) {
+	l := &HotkeyListener{
+		events:  make(chan HotkeyEvent),
+		closing: make(chan struct{}),
+		done:    make(chan struct{}),
+		ids:     make(map[int]struct{}),
+	}
+	errc := make(chan error, 1)
+
+	go func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		defer close(l.done)
+		defer close(l.events)
+
+		hwnd, err := createManagedWindow(hotkeyListenerClass, 0, 0, HWND_MESSAGE, l.wndProc)
+		if err != nil {
+			errc <- err
+			return
+		}
+		l.hwnd = hwnd
+		errc <- nil
+
+		_, _ = MessageLoop()
+	}()
+
+	if err := <-errc; err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *HotkeyListener) wndProc(hwnd windows.HWND, msg uint32, wParam, lParam uintptr) uintptr {
+	switch msg {
+	case WM_HOTKEY:
+		ev := HotkeyEvent{ID: int(wParam), Modifiers: uint32(lParam & 0xFFFF), VK: uint32(lParam >> 16 & 0xFFFF)}
+		select {
+		case l.events <- ev:
+		case <-l.closing:
+		}
+		return 0
+	case wmHotkeyRegister:
+		req := (*hotkeyRequest)(unsafe.Pointer(lParam))
+		req.err = registerHotKey(hwnd, req.id, req.modifiers, req.vk)
+		if req.err == nil {
+			l.ids[req.id] = struct{}{}
+		}
+		return 0
+	case wmHotkeyUnregister:
+		req := (*hotkeyRequest)(unsafe.Pointer(lParam))
+		req.err = unregisterHotKey(hwnd, req.id)
+		delete(l.ids, req.id)
+		return 0
+	case WM_DESTROY:
+		for id := range l.ids {
+			unregisterHotKey(hwnd, id)
+		}
+		PostQuitMessage(0)
+		return 0
+	}
+	return DefWindowProcW(hwnd, msg, wParam, lParam)
+}
+
+// Events returns the channel on which hotkey presses are delivered. It is closed by Close.
+// The listener thread waits until each event is received, so the channel should be drained promptly.
+func (l *HotkeyListener) Events() <-chan HotkeyEvent {
+	return l.events
+}
+
+// Register registers a system-wide hotkey. id identifies it in HotkeyEvent and Unregister.
+func (l *HotkeyListener) Register(id int, modifiers uint32, vk uint32) error {
+	return l.send(wmHotkeyRegister, &hotkeyRequest{id: id, modifiers: modifiers, vk: vk})
+}
+
+// Unregister frees a hotkey registered with Register.
+func (l *HotkeyListener) Unregister(id int) error {
+	return l.send(wmHotkeyUnregister, &hotkeyRequest{id: id})
+}
+
+// send runs req on the listener thread, since hotkeys can only be registered by the thread that owns the window.
+func (l *HotkeyListener) send(msg uint32, req *hotkeyRequest) error {
+	select {
+	case <-l.closing:
+		return windows.ERROR_INVALID_WINDOW_HANDLE
+	default:
+	}
+	// The window can be destroyed by Close after the check above, in which case SendMessageW fails
+	// without running the handler, so req.err keeps this value. The handler always overwrites it.
+	req.err = windows.ERROR_INVALID_WINDOW_HANDLE
+	SendMessageW(l.hwnd, msg, 0, uintptr(unsafe.Pointer(req)))
+	return req.err
+}
+
+// Close unregisters all hotkeys, destroys the window, closes the Events channel and waits for the listener thread to exit.
+func (l *HotkeyListener) Close() error {
+	var err error
+	l.closeOnce.Do(func() {
+		close(l.closing)
+		err = PostMessageW(l.hwnd, WM_CLOSE, 0, 0)
+		if err == nil {
+			<-l.done
+		}
+	})
+	return err
+}
@@ -0,0 +1,55 @@
+package win32utils
+
+import (
+	"runtime"
+	"testing"
+
+	"golang.org/x/sys/windows"
+)
+
+func TestHotkeyListener(t *testing.T) {
+	l, err := NewHotkeyListener()
+	if err != nil {
+		t.Fatalf("NewHotkeyListener() error = %v", err)
+	}
+
+	// Ctrl+Alt+Shift+F24 is very unlikely to be taken by another application.
+	const vkF24 = 0x87
+	if err := l.Register(1, MOD_CONTROL|MOD_ALT|MOD_SHIFT|MOD_NOREPEAT, vkF24); err != nil {
+		t.Errorf("Register() error = %v", err)
+	}
+	if err := l.Register(1, MOD_CONTROL|MOD_ALT|MOD_SHIFT|MOD_NOREPEAT, vkF24); err == nil {
+		t.Error("Register() of the same keys succeeded twice")
+	}
+
+	if err := l.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if _, ok := <-l.Events(); ok {
+		t.Error("Events() is still open after Close")
+	}
+	// The hotkey was unregistered by Close, so it can be registered again.
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	if err := RegisterHotKey(1, MOD_CONTROL|MOD_ALT|MOD_SHIFT|MOD_NOREPEAT, vkF24); err != nil {
+		t.Errorf("RegisterHotKey() after Close error = %v", err)
+	} else {
+		UnregisterHotKey(1)
+	}
+}
+
+func TestHotkeyListenerSendAfterDestroy(t *testing.T) {
+	l, err := NewHotkeyListener()
+	if err != nil {
+		t.Fatalf("NewHotkeyListener() error = %v", err)
+	}
+	if err := l.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	// Simulate Close destroying the window between the closing check and SendMessageW.
+	stale := &HotkeyListener{hwnd: l.hwnd, closing: make(chan struct{})}
+	if err := stale.Register(1, MOD_CONTROL|MOD_ALT|MOD_SHIFT, 0x87); err != windows.ERROR_INVALID_WINDOW_HANDLE {
+		t.Errorf("Register() on a destroyed window error = %v, want %v", err, windows.ERROR_INVALID_WINDOW_HANDLE)
+	}
+}
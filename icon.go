@@ -0,0 +1,97 @@
+package win32utils
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Flags for DrawIconEx.
+const (
+	DI_MASK        uint32 = 0x0001
+	DI_IMAGE       uint32 = 0x0002
+	DI_NORMAL      uint32 = 0x0003
+	DI_COMPAT      uint32 = 0x0004
+	DI_DEFAULTSIZE uint32 = 0x0008
+	DI_NOMIRROR    uint32 = 0x0010
+)
+
+// DrawIconEx of Win32 API. Check https://learn.microsoft.com/en-us/windows/win32/api/winuser/nf-winuser-drawiconex for more detail.
+func DrawIconEx(hdc windows.Handle, x, y int32, hIcon windows.Handle, cx, cy int32, stepIfAniCur uint32, hbrFlickerFree windows.Handle, flags uint32) error {
+	r1, _, err := User32.NewProc("DrawIconEx").Call(uintptr(hdc), uintptr(x), uintptr(y), uintptr(hIcon),
+		uintptr(cx), uintptr(cy), uintptr(stepIfAniCur), uintptr(hbrFlickerFree), uintptr(flags))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// DrawIcon draws hIcon at (x, y) with the system large icon size.
+func DrawIcon(hdc windows.Handle, x, y int32, hIcon windows.Handle) error {
+	return DrawIconEx(hdc, x, y, hIcon, GetSystemMetrics(SM_CXICON), GetSystemMetrics(SM_CYICON), 0, 0, DI_NORMAL)
+}
+
+// Image types for LoadImageW.
+const (
+	IMAGE_BITMAP uint32 = 0
+	IMAGE_ICON   uint32 = 1
+	IMAGE_CURSOR uint32 = 2
+)
+
+// Flags for LoadImageW.
+const (
+	LR_DEFAULTCOLOR     uint32 = 0x0000
+	LR_LOADFROMFILE     uint32 = 0x0010
+	LR_LOADTRANSPARENT  uint32 = 0x0020
+	LR_DEFAULTSIZE      uint32 = 0x0040
+	LR_CREATEDIBSECTION uint32 = 0x2000
+	LR_SHARED           uint32 = 0x8000
+)
+
+// LoadImageW of Win32 API. Check https://learn.microsoft.com/en-us/windows/win32/api/winuser/nf-winuser-loadimagew for more detail.
+// name is either a pointer to a UTF-16 string or a resource identifier.
+//
+//go:uintptrescapes
+func LoadImageW(hInst windows.Handle, name uintptr, imageType uint32, cx, cy int32, fuLoad uint32) (windows.Handle, error) {
+	r1, _, err := User32.NewProc("LoadImageW").Call(uintptr(hInst), name, uintptr(imageType),
+		uintptr(cx), uintptr(cy), uintptr(fuLoad))
+	if r1 == 0 {
+		return 0, err
+	}
+	return windows.Handle(r1), nil
+}
+
+// LoadIconFromFile loads the .ico file at path at the system large icon size.
+// The icon must be destroyed with DestroyIcon.
+func LoadIconFromFile(path string) (windows.Handle, error) {
+	pathPtr, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, err
+	}
+	return LoadImageW(0, uintptr(unsafe.Pointer(pathPtr)), IMAGE_ICON, 0, 0, LR_LOADFROMFILE|LR_DEFAULTSIZE)
+}
+
+// LoadIconFromResource loads the icon resource resourceID of the module instanceHandle at the
+// system large icon size. The icon must be destroyed with DestroyIcon.
+func LoadIconFromResource(instanceHandle windows.Handle, resourceID uint16) (windows.Handle, error) {
+	return LoadImageW(instanceHandle, uintptr(resourceID), IMAGE_ICON, 0, 0, LR_DEFAULTSIZE)
+}
+
+// LoadBitmapFromFile loads the .bmp file at path as a DIB section.
+// The bitmap must be deleted with DeleteObject.
+func LoadBitmapFromFile(path string) (windows.Handle, error) {
+	pathPtr, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, err
+	}
+	return LoadImageW(0, uintptr(unsafe.Pointer(pathPtr)), IMAGE_BITMAP, 0, 0, LR_LOADFROMFILE|LR_CREATEDIBSECTION)
+}
+
+// DestroyIcon destroys an icon loaded by LoadIconFromFile or LoadIconFromResource.
+func DestroyIcon(hIcon windows.Handle) error {
+	r1, _, err := User32.NewProc("DestroyIcon").Call(uintptr(hIcon))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
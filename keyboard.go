@@ -0,0 +1,221 @@
+package win32utils
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Virtual-key codes. The keys 0-9 and A-Z use their ASCII codes and have no constants.
+const (
+	VK_LBUTTON             uint32 = 0x01
+	VK_RBUTTON             uint32 = 0x02
+	VK_CANCEL              uint32 = 0x03
+	VK_MBUTTON             uint32 = 0x04
+	VK_XBUTTON1            uint32 = 0x05
+	VK_XBUTTON2            uint32 = 0x06
+	VK_BACK                uint32 = 0x08
+	VK_TAB                 uint32 = 0x09
+	VK_CLEAR               uint32 = 0x0C
+	VK_RETURN              uint32 = 0x0D
+	VK_SHIFT               uint32 = 0x10
+	VK_CONTROL             uint32 = 0x11
+	VK_MENU                uint32 = 0x12
+	VK_PAUSE               uint32 = 0x13
+	VK_CAPITAL             uint32 = 0x14
+	VK_KANA                uint32 = 0x15
+	VK_HANGUL              uint32 = 0x15
+	VK_IME_ON              uint32 = 0x16
+	VK_JUNJA               uint32 = 0x17
+	VK_FINAL               uint32 = 0x18
+	VK_HANJA               uint32 = 0x19
+	VK_KANJI               uint32 = 0x19
+	VK_IME_OFF             uint32 = 0x1A
+	VK_ESCAPE              uint32 = 0x1B
+	VK_CONVERT             uint32 = 0x1C
+	VK_NONCONVERT          uint32 = 0x1D
+	VK_ACCEPT              uint32 = 0x1E
+	VK_MODECHANGE          uint32 = 0x1F
+	VK_SPACE               uint32 = 0x20
+	VK_PRIOR               uint32 = 0x21
+	VK_NEXT                uint32 = 0x22
+	VK_END                 uint32 = 0x23
+	VK_HOME                uint32 = 0x24
+	VK_LEFT                uint32 = 0x25
+	VK_UP                  uint32 = 0x26
+	VK_RIGHT               uint32 = 0x27
+	VK_DOWN                uint32 = 0x28
+	VK_SELECT              uint32 = 0x29
+	VK_PRINT               uint32 = 0x2A
+	VK_EXECUTE             uint32 = 0x2B
+	VK_SNAPSHOT            uint32 = 0x2C
+	VK_INSERT              uint32 = 0x2D
+	VK_DELETE              uint32 = 0x2E
+	VK_HELP                uint32 = 0x2F
+	VK_LWIN                uint32 = 0x5B
+	VK_RWIN                uint32 = 0x5C
+	VK_APPS                uint32 = 0x5D
+	VK_SLEEP               uint32 = 0x5F
+	VK_NUMPAD0             uint32 = 0x60
+	VK_NUMPAD1             uint32 = 0x61
+	VK_NUMPAD2             uint32 = 0x62
+	VK_NUMPAD3             uint32 = 0x63
+	VK_NUMPAD4             uint32 = 0x64
+	VK_NUMPAD5             uint32 = 0x65
+	VK_NUMPAD6             uint32 = 0x66
+	VK_NUMPAD7             uint32 = 0x67
+	VK_NUMPAD8             uint32 = 0x68
+	VK_NUMPAD9             uint32 = 0x69
+	VK_MULTIPLY            uint32 = 0x6A
+	VK_ADD                 uint32 = 0x6B
+	VK_SEPARATOR           uint32 = 0x6C
+	VK_SUBTRACT            uint32 = 0x6D
+	VK_DECIMAL             uint32 = 0x6E
+	VK_DIVIDE              uint32 = 0x6F
+	VK_F1                  uint32 = 0x70
+	VK_F2                  uint32 = 0x71
+	VK_F3                  uint32 = 0x72
+	VK_F4                  uint32 = 0x73
+	VK_F5                  uint32 = 0x74
+	VK_F6                  uint32 = 0x75
+	VK_F7                  uint32 = 0x76
+	VK_F8                  uint32 = 0x77
+	VK_F9                  uint32 = 0x78
+	VK_F10                 uint32 = 0x79
+	VK_F11                 uint32 = 0x7A
+	VK_F12                 uint32 = 0x7B
+	VK_F13                 uint32 = 0x7C
+	VK_F14                 uint32 = 0x7D
+	VK_F15                 uint32 = 0x7E
+	VK_F16                 uint32 = 0x7F
+	VK_F17                 uint32 = 0x80
+	VK_F18                 uint32 = 0x81
+	VK_F19                 uint32 = 0x82
+	VK_F20                 uint32 = 0x83
+	VK_F21                 uint32 = 0x84
+	VK_F22                 uint32 = 0x85
+	VK_F23                 uint32 = 0x86
+	VK_F24                 uint32 = 0x87
+	VK_NUMLOCK             uint32 = 0x90
+	VK_SCROLL              uint32 = 0x91
+	VK_LSHIFT              uint32 = 0xA0
+	VK_RSHIFT              uint32 = 0xA1
+	VK_LCONTROL            uint32 = 0xA2
+	VK_RCONTROL            uint32 = 0xA3
+	VK_LMENU               uint32 = 0xA4
+	VK_RMENU               uint32 = 0xA5
+	VK_BROWSER_BACK        uint32 = 0xA6
+	VK_BROWSER_FORWARD     uint32 = 0xA7
+	VK_BROWSER_REFRESH     uint32 = 0xA8
+	VK_BROWSER_STOP        uint32 = 0xA9
+	VK_BROWSER_SEARCH      uint32 = 0xAA
+	VK_BROWSER_FAVORITES   uint32 = 0xAB
+	VK_BROWSER_HOME        uint32 = 0xAC
+	VK_VOLUME_MUTE         uint32 = 0xAD
+	VK_VOLUME_DOWN         uint32 = 0xAE
+	VK_VOLUME_UP           uint32 = 0xAF
+	VK_MEDIA_NEXT_TRACK    uint32 = 0xB0
+	VK_MEDIA_PREV_TRACK    uint32 = 0xB1
+	VK_MEDIA_STOP          uint32 = 0xB2
+	VK_MEDIA_PLAY_PAUSE    uint32 = 0xB3
+	VK_LAUNCH_MAIL         uint32 = 0xB4
+	VK_LAUNCH_MEDIA_SELECT uint32 = 0xB5
+	VK_LAUNCH_APP1         uint32 = 0xB6
+	VK_LAUNCH_APP2         uint32 = 0xB7
+	VK_OEM_1               uint32 = 0xBA
+	VK_OEM_PLUS            uint32 = 0xBB
+	VK_OEM_COMMA           uint32 = 0xBC
+	VK_OEM_MINUS           uint32 = 0xBD
+	VK_OEM_PERIOD          uint32 = 0xBE
+	VK_OEM_2               uint32 = 0xBF
+	VK_OEM_3               uint32 = 0xC0
+	VK_OEM_4               uint32 = 0xDB
+	VK_OEM_5               uint32 = 0xDC
+	VK_OEM_6               uint32 = 0xDD
+	VK_OEM_7               uint32 = 0xDE
+	VK_OEM_8               uint32 = 0xDF
+	VK_OEM_102             uint32 = 0xE2
+	VK_PROCESSKEY          uint32 = 0xE5
+	VK_PACKET              uint32 = 0xE7
+	VK_ATTN                uint32 = 0xF6
+	VK_CRSEL               uint32 = 0xF7
+	VK_EXSEL               uint32 = 0xF8
+	VK_EREOF               uint32 = 0xF9
+	VK_PLAY                uint32 = 0xFA
+	VK_ZOOM                uint32 = 0xFB
+	VK_NONAME              uint32 = 0xFC
+	VK_PA1                 uint32 = 0xFD
+	VK_OEM_CLEAR           uint32 = 0xFE
+)
+
+// Translation types for MapVirtualKeyW.
+const (
+	MAPVK_VK_TO_VSC    uint32 = 0
+	MAPVK_VSC_TO_VK    uint32 = 1
+	MAPVK_VK_TO_CHAR   uint32 = 2
+	MAPVK_VSC_TO_VK_EX uint32 = 3
+)
+
+// MapVirtualKeyW translates between virtual-key codes, scan codes and characters. It returns 0 if there is no translation.
+func MapVirtualKeyW(code, mapType uint32) uint32 {
+	r1, _, _ := User32.NewProc("MapVirtualKeyW").Call(uintptr(code), uintptr(mapType))
+	return uint32(r1)
+}
+
+// GetKeyNameTextW retrieves the name of a key. lParam has the layout of the lParam of WM_KEYDOWN:
+// the scan code in bits 16-23 and the extended-key flag in bit 24.
+func GetKeyNameTextW(lParam int32) (string, error) {
+	var buf [64]uint16
+	r1, _, err := User32.NewProc("GetKeyNameTextW").Call(uintptr(lParam), uintptr(unsafe.Pointer(&buf[0])), uintptr(len(buf)))
+	if r1 == 0 {
+		if err == windows.ERROR_SUCCESS {
+			return "", windows.ERROR_INVALID_PARAMETER
+		}
+		return "", err
+	}
+	return windows.UTF16ToString(buf[:r1]), nil
+}
+
+// VirtualKeyName returns the display name of a virtual key in the current keyboard layout, such as "F5" for VK_F5.
+// If scanCode is 0 it is looked up with MapVirtualKeyW.
+func VirtualKeyName(vk, scanCode uint32) (string, error) {
+	if scanCode == 0 {
+		scanCode = MapVirtualKeyW(vk, MAPVK_VK_TO_VSC)
+	}
+	lParam := int32(scanCode&0xFF) << 16
+	if isExtendedKey(vk) {
+		lParam |= 1 << 24
+	}
+	return GetKeyNameTextW(lParam)
+}
+
+// isExtendedKey reports whether vk shares its scan code with a numeric keypad key
+// and needs the extended-key flag to be told apart.
+func isExtendedKey(vk uint32) bool {
+	switch vk {
+	case VK_PRIOR, VK_NEXT, VK_END, VK_HOME, VK_LEFT, VK_UP, VK_RIGHT, VK_DOWN,
+		VK_INSERT, VK_DELETE, VK_LWIN, VK_RWIN, VK_APPS, VK_DIVIDE, VK_NUMLOCK, VK_RCONTROL, VK_RMENU:
+		return true
+	}
+	return false
+}
+
+// GetAsyncKeyState reports the state of the key vk at the time of the call. Bit 15 is set while the
+// key is down; bit 0 is set if it was pressed since the previous call by any thread, which is
+// unreliable since other applications share it. Unlike GetKeyState, it reads the physical state
+// regardless of which thread or process has the keyboard focus. It returns 0 when the foreground
+// window belongs to another desktop.
+func GetAsyncKeyState(vk uint32) uint16 {
+	r1, _, _ := User32.NewProc("GetAsyncKeyState").Call(uintptr(vk))
+	return uint16(r1)
+}
+
+// IsKeyPressed reports whether the key vk is down.
+func IsKeyPressed(vk uint32) bool {
+	return GetAsyncKeyState(vk)&0x8000 != 0
+}
+
+// WasKeyPressed reports whether the key vk was pressed since the previous GetAsyncKeyState call.
+func WasKeyPressed(vk uint32) bool {
+	return GetAsyncKeyState(vk)&0x0001 != 0
+}
@@ -0,0 +1,41 @@
+package win32utils
+
+import "testing"
+
+func TestVirtualKeyName(t *testing.T) {
+	for _, vk := range []uint32{VK_F5, VK_RETURN, VK_SPACE} {
+		name, err := VirtualKeyName(vk, 0)
+		if err != nil {
+			t.Errorf("VirtualKeyName(0x%X) error = %v", vk, err)
+			continue
+		}
+		if name == "" {
+			t.Errorf("VirtualKeyName(0x%X) = %q, want a non-empty name", vk, name)
+		}
+	}
+
+	// Key names are localized, but function keys are named the same everywhere.
+	if name, _ := VirtualKeyName(VK_F5, 0); name != "F5" {
+		t.Errorf("VirtualKeyName(VK_F5) = %q, want %q", name, "F5")
+	}
+}
+
+func TestGetAsyncKeyState(t *testing.T) {
+	// Nothing presses these keys while the tests run.
+	tests := []struct {
+		name string
+		vk   uint32
+	}{
+		{"VK_F24", VK_F24},
+		{"VK_OEM_CLEAR", VK_OEM_CLEAR},
+		{"VK_BROWSER_FAVORITES", VK_BROWSER_FAVORITES},
+	}
+	for _, tt := range tests {
+		if IsKeyPressed(tt.vk) {
+			t.Errorf("IsKeyPressed(%s) = true, want false", tt.name)
+		}
+		if state := GetAsyncKeyState(tt.vk); state&0x8000 != 0 {
+			t.Errorf("GetAsyncKeyState(%s) = %#x, want the down bit clear", tt.name, state)
+		}
+	}
+}
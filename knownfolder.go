@@ -0,0 +1,49 @@
+package win32utils
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Known folder identifiers for GetKnownFolderPath.
+var (
+	FOLDERID_Desktop        = windows.GUID{Data1: 0xB4BFCC3A, Data2: 0xDB2C, Data3: 0x424C, Data4: [8]byte{0xB0, 0x29, 0x7F, 0xE9, 0x9A, 0x87, 0xC6, 0x41}}
+	FOLDERID_Documents      = windows.GUID{Data1: 0xFDD39AD0, Data2: 0x238F, Data3: 0x46AF, Data4: [8]byte{0xAD, 0xB4, 0x6C, 0x85, 0x48, 0x03, 0x69, 0xC7}}
+	FOLDERID_Downloads      = windows.GUID{Data1: 0x374DE290, Data2: 0x123F, Data3: 0x4565, Data4: [8]byte{0x91, 0x64, 0x39, 0xC4, 0x92, 0x5E, 0x46, 0x7B}}
+	FOLDERID_RoamingAppData = windows.GUID{Data1: 0x3EB685DB, Data2: 0x65F9, Data3: 0x4CF6, Data4: [8]byte{0xA0, 0x3A, 0xE3, 0xEF, 0x65, 0x72, 0x9F, 0x3D}}
+	FOLDERID_LocalAppData   = windows.GUID{Data1: 0xF1B32785, Data2: 0x6FBA, Data3: 0x4FCF, Data4: [8]byte{0x9D, 0x55, 0x7B, 0x8E, 0x7F, 0x15, 0x70, 0x91}}
+	FOLDERID_Pictures       = windows.GUID{Data1: 0x33E28130, Data2: 0x4E1E, Data3: 0x4676, Data4: [8]byte{0x83, 0x5A, 0x98, 0x39, 0x5C, 0x3B, 0xC3, 0xBB}}
+	FOLDERID_ProgramFiles   = windows.GUID{Data1: 0x905E63B6, Data2: 0xC1BF, Data3: 0x494E, Data4: [8]byte{0xB2, 0x9C, 0x65, 0xB7, 0x32, 0xD3, 0xD2, 0x1A}}
+)
+
+// GetKnownFolderPath returns the path of the known folder folderID, one of the FOLDERID_ values,
+// for the current user.
+func GetKnownFolderPath(folderID windows.GUID) (string, error) {
+	var path *uint16
+	r1, _, _ := Shell32.NewProc("SHGetKnownFolderPath").Call(uintptr(unsafe.Pointer(&folderID)), 0, 0, uintptr(unsafe.Pointer(&path)))
+	// The buffer is allocated even on failure.
+	defer Ole32.NewProc("CoTaskMemFree").Call(uintptr(unsafe.Pointer(path)))
+	if err := hresultError(r1); err != nil {
+		return "", err
+	}
+	return windows.UTF16PtrToString(path), nil
+}
+
+// DesktopPath returns the path of the user's desktop, or "" if it cannot be determined.
+func DesktopPath() string {
+	path, _ := GetKnownFolderPath(FOLDERID_Desktop)
+	return path
+}
+
+// DocumentsPath returns the path of the user's documents folder, or "" if it cannot be determined.
+func DocumentsPath() string {
+	path, _ := GetKnownFolderPath(FOLDERID_Documents)
+	return path
+}
+
+// AppDataPath returns the path of the user's roaming application data folder, or "" if it cannot be determined.
+func AppDataPath() string {
+	path, _ := GetKnownFolderPath(FOLDERID_RoamingAppData)
+	return path
+}
@@ -0,0 +1,35 @@
+package win32utils
+
+import (
+	"os"
+	"testing"
+
+	"golang.org/x/sys/windows"
+)
+
+func TestGetKnownFolderPath(t *testing.T) {
+	folders := map[string]windows.GUID{
+		"Desktop":        FOLDERID_Desktop,
+		"Documents":      FOLDERID_Documents,
+		"LocalAppData":   FOLDERID_LocalAppData,
+		"RoamingAppData": FOLDERID_RoamingAppData,
+		"ProgramFiles":   FOLDERID_ProgramFiles,
+	}
+	for name, id := range folders {
+		path, err := GetKnownFolderPath(id)
+		if err != nil {
+			t.Errorf("GetKnownFolderPath(%s) error = %v", name, err)
+			continue
+		}
+		if fi, err := os.Stat(path); err != nil || !fi.IsDir() {
+			t.Errorf("GetKnownFolderPath(%s) = %q, which is not an existing directory", name, path)
+		}
+	}
+
+	if _, err := GetKnownFolderPath(windows.GUID{Data1: 1}); err == nil {
+		t.Error("GetKnownFolderPath() of an unknown folder succeeded")
+	}
+	if AppDataPath() == "" {
+		t.Error("AppDataPath() is empty")
+	}
+}
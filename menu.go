@@ -0,0 +1,192 @@
+package win32utils
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Flags for AppendMenuW, EnableMenuItem and CheckMenuItem.
+const (
+	MF_BYCOMMAND  uint32 = 0x0000
+	MF_STRING     uint32 = 0x0000
+	MF_ENABLED    uint32 = 0x0000
+	MF_UNCHECKED  uint32 = 0x0000
+	MF_GRAYED     uint32 = 0x0001
+	MF_DISABLED   uint32 = 0x0002
+	MF_CHECKED    uint32 = 0x0008
+	MF_POPUP      uint32 = 0x0010
+	MF_BYPOSITION uint32 = 0x0400
+	MF_SEPARATOR  uint32 = 0x0800
+)
+
+// Menu item states of MENUITEMINFOW.FState.
+const (
+	MFS_ENABLED   uint32 = 0x0000
+	MFS_UNCHECKED uint32 = 0x0000
+	MFS_GRAYED    uint32 = 0x0003
+	MFS_DISABLED  uint32 = MFS_GRAYED
+	MFS_CHECKED   uint32 = 0x0008
+	MFS_DEFAULT   uint32 = 0x1000
+)
+
+// Members of MENUITEMINFOW selected by FMask.
+const (
+	MIIM_STATE   uint32 = 0x0001
+	MIIM_ID      uint32 = 0x0002
+	MIIM_SUBMENU uint32 = 0x0004
+	MIIM_STRING  uint32 = 0x0040
+	MIIM_FTYPE   uint32 = 0x0100
+)
+
+// MENUITEMINFOW describes a menu item for GetMenuItemInfoW and SetMenuItemInfoW.
+type MENUITEMINFOW struct {
+	CbSize        uint32
+	FMask         uint32
+	FType         uint32
+	FState        uint32
+	WID           uint32
+	HSubMenu      windows.Handle
+	HbmpChecked   windows.Handle
+	HbmpUnchecked windows.Handle
+	DwItemData    uintptr
+	DwTypeData    *uint16
+	Cch           uint32
+	HbmpItem      windows.Handle
+}
+
+// CreatePopupMenu creates an empty drop-down or context menu. It must be destroyed with DestroyMenu
+// unless it is attached to a window or to another menu.
+func CreatePopupMenu() (windows.Handle, error) {
+	r1, _, err := User32.NewProc("CreatePopupMenu").Call()
+	if r1 == 0 {
+		return 0, err
+	}
+	return windows.Handle(r1), nil
+}
+
+// DestroyMenu destroys hMenu and, recursively, its submenus.
+func DestroyMenu(hMenu windows.Handle) error {
+	r1, _, err := User32.NewProc("DestroyMenu").Call(uintptr(hMenu))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// AppendMenuW appends an item to hMenu. id is the command identifier, or the submenu handle with MF_POPUP.
+func AppendMenuW(hMenu windows.Handle, flags uint32, id uintptr, item string) error {
+	itemPtr, err := windows.UTF16PtrFromString(item)
+	if err != nil {
+		return err
+	}
+	r1, _, err := User32.NewProc("AppendMenuW").Call(uintptr(hMenu), uintptr(flags), id, uintptr(unsafe.Pointer(itemPtr)))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// InsertMenuW inserts an item into hMenu before the item at position, a position with MF_BYPOSITION
+// or a command identifier otherwise. id is the command identifier, or the submenu handle with MF_POPUP.
+func InsertMenuW(hMenu windows.Handle, position uint32, flags uint32, id uintptr, item string) error {
+	itemPtr, err := windows.UTF16PtrFromString(item)
+	if err != nil {
+		return err
+	}
+	r1, _, err := User32.NewProc("InsertMenuW").Call(uintptr(hMenu), uintptr(position), uintptr(flags), id, uintptr(unsafe.Pointer(itemPtr)))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// AppendSubMenu appends an item labeled label to hMenu that opens a new, empty submenu, and
+// returns the submenu. It is destroyed together with hMenu.
+func AppendSubMenu(hMenu windows.Handle, label string) (windows.Handle, error) {
+	sub, err := CreatePopupMenu()
+	if err != nil {
+		return 0, err
+	}
+	err = AppendMenuW(hMenu, MF_POPUP|MF_STRING, uintptr(sub), label)
+	if err != nil {
+		DestroyMenu(sub)
+		return 0, err
+	}
+	return sub, nil
+}
+
+// GetMenuItemCount returns the number of items in hMenu.
+func GetMenuItemCount(hMenu windows.Handle) (int32, error) {
+	r1, _, err := User32.NewProc("GetMenuItemCount").Call(uintptr(hMenu))
+	if int32(r1) == -1 {
+		return 0, err
+	}
+	return int32(r1), nil
+}
+
+// GetSubMenu returns the submenu opened by the item at position in hMenu, or 0 if it does not open one.
+func GetSubMenu(hMenu windows.Handle, position int32) windows.Handle {
+	r1, _, _ := User32.NewProc("GetSubMenu").Call(uintptr(hMenu), uintptr(position))
+	return windows.Handle(r1)
+}
+
+// EnableMenuItem enables, disables or grays the item of hMenu and returns its previous MF_GRAYED
+// and MF_DISABLED state. flags combines MF_BYCOMMAND or MF_BYPOSITION with MF_ENABLED, MF_DISABLED or MF_GRAYED.
+func EnableMenuItem(hMenu windows.Handle, item uint32, flags uint32) (uint32, error) {
+	r1, _, _ := User32.NewProc("EnableMenuItem").Call(uintptr(hMenu), uintptr(item), uintptr(flags))
+	// -1 means that the item does not exist; the last error is not set.
+	if int32(r1) == -1 {
+		return 0, windows.ERROR_INVALID_PARAMETER
+	}
+	return uint32(r1), nil
+}
+
+// CheckMenuItem sets the check mark of the item of hMenu and returns its previous MF_CHECKED state.
+// flags combines MF_BYCOMMAND or MF_BYPOSITION with MF_CHECKED or MF_UNCHECKED.
+func CheckMenuItem(hMenu windows.Handle, item uint32, flags uint32) (uint32, error) {
+	r1, _, _ := User32.NewProc("CheckMenuItem").Call(uintptr(hMenu), uintptr(item), uintptr(flags))
+	if int32(r1) == -1 {
+		return 0, windows.ERROR_INVALID_PARAMETER
+	}
+	return uint32(r1), nil
+}
+
+// GetMenuItemInfoW retrieves the members of the item of hMenu selected by info.FMask.
+// item is a command identifier, or a position if byPosition is set. CbSize is filled in.
+func GetMenuItemInfoW(hMenu windows.Handle, item uint32, byPosition bool, info *MENUITEMINFOW) error {
+	var fByPosition uintptr
+	if byPosition {
+		fByPosition = 1
+	}
+	info.CbSize = uint32(unsafe.Sizeof(*info))
+	r1, _, err := User32.NewProc("GetMenuItemInfoW").Call(uintptr(hMenu), uintptr(item), fByPosition, uintptr(unsafe.Pointer(info)))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// SetMenuItemInfoW changes the members of the item of hMenu selected by info.FMask.
+// item is a command identifier, or a position if byPosition is set. CbSize is filled in.
+func SetMenuItemInfoW(hMenu windows.Handle, item uint32, byPosition bool, info *MENUITEMINFOW) error {
+	var fByPosition uintptr
+	if byPosition {
+		fByPosition = 1
+	}
+	info.CbSize = uint32(unsafe.Sizeof(*info))
+	r1, _, err := User32.NewProc("SetMenuItemInfoW").Call(uintptr(hMenu), uintptr(item), fByPosition, uintptr(unsafe.Pointer(info)))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// SetMenuItemLabel replaces the text of the item with command identifier id.
+func SetMenuItemLabel(hMenu windows.Handle, id uint32, label string) error {
+	labelPtr, err := windows.UTF16PtrFromString(label)
+	if err != nil {
+		return err
+	}
+	return SetMenuItemInfoW(hMenu, id, false, &MENUITEMINFOW{FMask: MIIM_STRING, DwTypeData: labelPtr})
+}
@@ -0,0 +1,99 @@
+package win32utils
+
+import (
+	"testing"
+
+	"golang.org/x/sys/windows"
+)
+
+func TestMenuItemState(t *testing.T) {
+	const id = 100
+	menu, err := CreatePopupMenu()
+	if err != nil {
+		t.Fatalf("CreatePopupMenu() error = %v", err)
+	}
+	defer DestroyMenu(menu)
+	if err := AppendMenuW(menu, MF_STRING, id, "Item"); err != nil {
+		t.Fatalf("AppendMenuW() error = %v", err)
+	}
+
+	state := func() uint32 {
+		info := MENUITEMINFOW{FMask: MIIM_STATE}
+		if err := GetMenuItemInfoW(menu, id, false, &info); err != nil {
+			t.Fatalf("GetMenuItemInfoW() error = %v", err)
+		}
+		return info.FState
+	}
+
+	if _, err := EnableMenuItem(menu, id, MF_BYCOMMAND|MF_GRAYED); err != nil {
+		t.Fatalf("EnableMenuItem() error = %v", err)
+	}
+	if got := state(); got&MFS_GRAYED == 0 {
+		t.Errorf("state after disabling = %#x, want grayed", got)
+	}
+	if prev, err := EnableMenuItem(menu, id, MF_BYCOMMAND|MF_ENABLED); err != nil || prev&MF_GRAYED == 0 {
+		t.Errorf("EnableMenuItem() = %#x, %v, want MF_GRAYED", prev, err)
+	}
+	if got := state(); got&MFS_GRAYED != 0 {
+		t.Errorf("state after enabling = %#x, want MFS_ENABLED", got)
+	}
+
+	if _, err := CheckMenuItem(menu, id, MF_BYCOMMAND|MF_CHECKED); err != nil {
+		t.Fatalf("CheckMenuItem() error = %v", err)
+	}
+	if got := state(); got&MFS_CHECKED == 0 {
+		t.Errorf("state after checking = %#x, want MFS_CHECKED", got)
+	}
+
+	if err := SetMenuItemLabel(menu, id, "Renamed"); err != nil {
+		t.Fatalf("SetMenuItemLabel() error = %v", err)
+	}
+	buf := make([]uint16, 32)
+	info := MENUITEMINFOW{FMask: MIIM_STRING, DwTypeData: &buf[0], Cch: uint32(len(buf))}
+	if err := GetMenuItemInfoW(menu, id, false, &info); err != nil {
+		t.Fatalf("GetMenuItemInfoW() error = %v", err)
+	}
+	if got := windows.UTF16ToString(buf); got != "Renamed" {
+		t.Errorf("label = %q, want %q", got, "Renamed")
+	}
+
+	if _, err := EnableMenuItem(menu, id+1, MF_BYCOMMAND|MF_GRAYED); err != windows.ERROR_INVALID_PARAMETER {
+		t.Errorf("EnableMenuItem() on a missing item error = %v, want %v", err, windows.ERROR_INVALID_PARAMETER)
+	}
+}
+
+func TestAppendSubMenu(t *testing.T) {
+	menu, err := CreatePopupMenu()
+	if err != nil {
+		t.Fatalf("CreatePopupMenu() error = %v", err)
+	}
+	defer DestroyMenu(menu)
+
+	if err := AppendMenuW(menu, MF_STRING, 1, "Last"); err != nil {
+		t.Fatalf("AppendMenuW() error = %v", err)
+	}
+	sub, err := AppendSubMenu(menu, "More")
+	if err != nil {
+		t.Fatalf("AppendSubMenu() error = %v", err)
+	}
+	if err := AppendMenuW(sub, MF_STRING, 2, "Nested"); err != nil {
+		t.Fatalf("AppendMenuW() on submenu error = %v", err)
+	}
+	if err := InsertMenuW(menu, 0, MF_BYPOSITION|MF_STRING, 3, "First"); err != nil {
+		t.Fatalf("InsertMenuW() error = %v", err)
+	}
+
+	if n, err := GetMenuItemCount(menu); err != nil || n != 3 {
+		t.Errorf("GetMenuItemCount() = %d, %v, want 3", n, err)
+	}
+	info := MENUITEMINFOW{FMask: MIIM_ID}
+	if err := GetMenuItemInfoW(menu, 0, true, &info); err != nil || info.WID != 3 {
+		t.Errorf("first item ID = %d, %v, want 3", info.WID, err)
+	}
+	if got := GetSubMenu(menu, 2); got != sub {
+		t.Errorf("GetSubMenu(2) = %#x, want %#x", got, sub)
+	}
+	if n, _ := GetMenuItemCount(sub); n != 1 {
+		t.Errorf("GetMenuItemCount(sub) = %d, want 1", n)
+	}
+}
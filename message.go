@@ -0,0 +1,203 @@
+package win32utils
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Window messages.
+const (
+	WM_DESTROY        uint32 = 0x0002
+	WM_SETFOCUS       uint32 = 0x0007
+	WM_KILLFOCUS      uint32 = 0x0008
+	WM_CLOSE          uint32 = 0x0010
+	WM_QUIT           uint32 = 0x0012
+	WM_SYSCOLORCHANGE uint32 = 0x0015
+	WM_SETTINGCHANGE  uint32 = 0x001A
+	WM_GETMINMAXINFO  uint32 = 0x0024
+	WM_CONTEXTMENU    uint32 = 0x007B
+	WM_NCDESTROY      uint32 = 0x0082
+	WM_INPUT          uint32 = 0x00FF
+	WM_TIMER          uint32 = 0x0113
+	WM_MOUSEMOVE      uint32 = 0x0200
+	WM_LBUTTONDOWN    uint32 = 0x0201
+	WM_LBUTTONUP      uint32 = 0x0202
+	WM_RBUTTONDOWN    uint32 = 0x0204
+	WM_RBUTTONUP      uint32 = 0x0205
+	WM_MBUTTONDOWN    uint32 = 0x0207
+	WM_MBUTTONUP      uint32 = 0x0208
+	WM_MOUSEWHEEL     uint32 = 0x020A
+	WM_XBUTTONDOWN    uint32 = 0x020B
+	WM_XBUTTONUP      uint32 = 0x020C
+	WM_MOUSEHWHEEL    uint32 = 0x020E
+	WM_DROPFILES      uint32 = 0x0233
+	WM_MOUSEHOVER     uint32 = 0x02A1
+	WM_MOUSELEAVE     uint32 = 0x02A3
+	WM_DPICHANGED     uint32 = 0x02E0
+	WM_HOTKEY         uint32 = 0x0312
+	WM_THEMECHANGED   uint32 = 0x031A
+	WM_USER           uint32 = 0x0400
+	WM_APP            uint32 = 0x8000
+)
+
+// MSG contains message information from a thread's message queue.
+type MSG struct {
+	Hwnd     windows.HWND
+	Message  uint32
+	WParam   uintptr
+	LParam   uintptr
+	Time     uint32
+	Pt       POINT
+	LPrivate uint32
+}
+
+// IsDialogMessage determines whether msg is intended for the dialog box dlg and, if it is, processes it.
+// Message loops should call it before TranslateMessage and DispatchMessageW so that Tab and arrow keys
+// move the focus between controls. Only controls with the WS_TABSTOP style take part in Tab navigation.
+func IsDialogMessage(dlg windows.HWND, msg *MSG) bool {
+	r1, _, _ := User32.NewProc("IsDialogMessageW").Call(uintptr(dlg), uintptr(unsafe.Pointer(msg)))
+	return r1 != 0
+}
+
+// GetMessageW retrieves a message from the calling thread's message queue.
+// It returns false when WM_QUIT is retrieved.
+func GetMessageW(msg *MSG, hwnd windows.HWND, msgFilterMin, msgFilterMax uint32) (bool, error) {
+	r1, _, err := User32.NewProc("GetMessageW").Call(
+		uintptr(unsafe.Pointer(msg)),
+		uintptr(hwnd),
+		uintptr(msgFilterMin),
+		uintptr(msgFilterMax))
+	if int32(r1) == -1 {
+		return false, err
+	}
+	return r1 != 0, nil
+}
+
+// TranslateMessage translates virtual-key messages into character messages.
+func TranslateMessage(msg *MSG) bool {
+	r1, _, _ := User32.NewProc("TranslateMessage").Call(uintptr(unsafe.Pointer(msg)))
+	return r1 != 0
+}
+
+// DispatchMessageW dispatches a message to a window procedure.
+func DispatchMessageW(msg *MSG) uintptr {
+	r1, _, _ := User32.NewProc("DispatchMessageW").Call(uintptr(unsafe.Pointer(msg)))
+	return r1
+}
+
+// Removal options for PeekMessageW.
+const (
+	PM_NOREMOVE uint32 = 0x0000
+	PM_REMOVE   uint32 = 0x0001
+)
+
+// PeekMessageW checks the message queue of the calling thread without blocking and reports whether a message was available.
+// With PM_REMOVE the message is taken off the queue; with PM_NOREMOVE it stays there. Unlike GetMessageW
+// it does not treat WM_QUIT specially, so callers must check msg.Message themselves.
+func PeekMessageW(msg *MSG, hwnd windows.HWND, msgFilterMin, msgFilterMax, removeMsg uint32) bool {
+	r1, _, _ := User32.NewProc("PeekMessageW").Call(uintptr(unsafe.Pointer(msg)), uintptr(hwnd),
+		uintptr(msgFilterMin), uintptr(msgFilterMax), uintptr(removeMsg))
+	return r1 != 0
+}
+
+// PeekAndDispatch removes at most one message from the queue of the calling thread and dispatches it,
+// without blocking. It reports whether a message was available and whether it was WM_QUIT, which is
+// not dispatched. Pass hwnd 0 so that WM_QUIT, a thread message, can be seen. Calling it repeatedly
+// forms a non-blocking message pump, for example in a game loop.
+func PeekAndDispatch(hwnd windows.HWND) (available, quit bool) {
+	var msg MSG
+	if !PeekMessageW(&msg, hwnd, 0, 0, PM_REMOVE) {
+		return false, false
+	}
+	if msg.Message == WM_QUIT {
+		return true, true
+	}
+	TranslateMessage(&msg)
+	DispatchMessageW(&msg)
+	return true, false
+}
+
+// Flags for SendMessageTimeoutW.
+const (
+	SMTO_NORMAL             uint32 = 0x0000
+	SMTO_BLOCK              uint32 = 0x0001
+	SMTO_ABORTIFHUNG        uint32 = 0x0002
+	SMTO_NOTIMEOUTIFNOTHUNG uint32 = 0x0008
+	SMTO_ERRORONEXIT        uint32 = 0x0020
+)
+
+// RegisterWindowMessageW defines a window message that is unique throughout the system,
+// such as "TaskbarButtonCreated". Every caller that registers the same name gets the same message.
+func RegisterWindowMessageW(name string) (uint32, error) {
+	namePtr, err := windows.UTF16PtrFromString(name)
+	if err != nil {
+		return 0, err
+	}
+	r1, _, err := User32.NewProc("RegisterWindowMessageW").Call(uintptr(unsafe.Pointer(namePtr)))
+	if r1 == 0 {
+		return 0, err
+	}
+	return uint32(r1), nil
+}
+
+// SendMessageW sends a message to hwnd and waits until its window procedure has processed it.
+// lParam and wParam may be pointers converted with uintptr(unsafe.Pointer(p)) in the call expression;
+// they are kept alive and in place until SendMessageW returns.
+//
+//go:uintptrescapes
+func SendMessageW(hwnd windows.HWND, msg uint32, wParam, lParam uintptr) uintptr {
+	r1, _, _ := User32.NewProc("SendMessageW").Call(uintptr(hwnd), uintptr(msg), wParam, lParam)
+	return r1
+}
+
+// SendMessageTimeoutW sends a message to hwnd and waits at most timeoutMs milliseconds for it to be
+// processed. It returns the result of the window procedure. A timeout, or a hung window with
+// SMTO_ABORTIFHUNG, is reported as windows.ERROR_TIMEOUT.
+//
+//go:uintptrescapes
+func SendMessageTimeoutW(hwnd windows.HWND, msg uint32, wParam, lParam uintptr, flags uint32, timeoutMs uint32) (uintptr, error) {
+	var result uintptr
+	r1, _, err := User32.NewProc("SendMessageTimeoutW").Call(uintptr(hwnd), uintptr(msg), wParam, lParam,
+		uintptr(flags), uintptr(timeoutMs), uintptr(unsafe.Pointer(&result)))
+	if r1 == 0 {
+		if err == windows.ERROR_SUCCESS {
+			return 0, windows.ERROR_TIMEOUT
+		}
+		return 0, err
+	}
+	return result, nil
+}
+
+// PostMessageW places a message in the message queue associated with the thread that created hwnd
+// and returns without waiting for the thread to process the message.
+func PostMessageW(hwnd windows.HWND, msg uint32, wParam, lParam uintptr) error {
+	r1, _, err := User32.NewProc("PostMessageW").Call(uintptr(hwnd), uintptr(msg), wParam, lParam)
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// MessageLoop retrieves and dispatches messages for the calling thread until WM_QUIT arrives,
+// and returns the exit code passed to PostQuitMessage. The exit code is an int32 on both
+// 32- and 64-bit Windows, so taking the low 32 bits of the WM_QUIT wParam loses nothing.
+func MessageLoop() (int32, error) {
+	var msg MSG
+	for {
+		ok, err := GetMessageW(&msg, 0, 0, 0)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			return int32(msg.WParam), nil
+		}
+		TranslateMessage(&msg)
+		DispatchMessageW(&msg)
+	}
+}
+
+// PostQuitMessage indicates to the system that the calling thread has made a request to terminate.
+func PostQuitMessage(exitCode int32) {
+	_, _, _ = User32.NewProc("PostQuitMessage").Call(uintptr(exitCode))
+}
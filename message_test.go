@@ -0,0 +1,65 @@
+package win32utils
+
+import (
+	"runtime"
+	"testing"
+
+	"golang.org/x/sys/windows"
+)
+
+func TestMessageLoopExitCode(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	PostQuitMessage(42)
+	code, err := MessageLoop()
+	if err != nil {
+		t.Fatalf("MessageLoop() error = %v", err)
+	}
+	if code != 42 {
+		t.Errorf("MessageLoop() = %d, want 42", code)
+	}
+}
+
+func TestPeekAndDispatch(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// Drain anything left over from other tests on this thread.
+	var msg MSG
+	for PeekMessageW(&msg, 0, 0, 0, PM_REMOVE) {
+	}
+
+	if available, quit := PeekAndDispatch(0); available || quit {
+		t.Errorf("PeekAndDispatch() = %v, %v, want false, false", available, quit)
+	}
+	PostQuitMessage(0)
+	if available, quit := PeekAndDispatch(0); !available || !quit {
+		t.Errorf("PeekAndDispatch() = %v, %v, want true, true", available, quit)
+	}
+}
+
+func TestSendMessageTimeoutW(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	hwnd, err := createManagedWindow("win32utils_test_sendmessage", 0, 0, HWND_MESSAGE,
+		func(hwnd windows.HWND, msg uint32, wParam, lParam uintptr) uintptr {
+			if msg == WM_USER {
+				return wParam + lParam
+			}
+			return DefWindowProcW(hwnd, msg, wParam, lParam)
+		})
+	if err != nil {
+		t.Fatalf("createManagedWindow() error = %v", err)
+	}
+	defer DestroyWindow(hwnd)
+
+	if got := SendMessageW(hwnd, WM_USER, 40, 2); got != 42 {
+		t.Errorf("SendMessageW() = %d, want 42", got)
+	}
+	got, err := SendMessageTimeoutW(hwnd, WM_USER, 0, 0, SMTO_NORMAL, 1000)
+	if err != nil || got != 0 {
+		t.Errorf("SendMessageTimeoutW() = %d, %v, want 0, nil", got, err)
+	}
+}
@@ -6,18 +6,15 @@ import (
 	"golang.org/x/sys/windows"
 )
 
-// RunningByDoubleClick Check if run directly by double-clicking
+// RunningByDoubleClick Check if run directly by double-clicking.
+// It reports whether this process is the only one attached to its console, so it is false
+// for a process without a console, such as a windowsgui binary.
 func RunningByDoubleClick() bool {
-	lp := Kernel32.NewProc("GetConsoleProcessList")
-	if lp != nil {
-		var ids [2]uint32
-		var maxCount uint32 = 2
-		ret, _, _ := lp.Call(uintptr(unsafe.Pointer(&ids)), uintptr(maxCount))
-		if ret > 1 {
-			return false
-		}
+	pids, err := GetConsoleProcessList()
+	if err != nil {
+		return false
 	}
-	return true
+	return len(pids) == 1
 }
 
 // MessageBoxW of Win32 API. Check https://docs.microsoft.com/en-us/windows/win32/api/winuser/nf-winuser-messageboxw for more detail.
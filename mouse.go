@@ -0,0 +1,126 @@
+package win32utils
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Flags for TRACKMOUSEEVENT.
+const (
+	TME_HOVER     uint32 = 0x00000001
+	TME_LEAVE     uint32 = 0x00000002
+	TME_NONCLIENT uint32 = 0x00000010
+	TME_QUERY     uint32 = 0x40000000
+	TME_CANCEL    uint32 = 0x80000000
+
+	HOVER_DEFAULT uint32 = 0xFFFFFFFF
+)
+
+// Key state flags in the wParam of mouse messages.
+const (
+	MK_LBUTTON  uint16 = 0x0001
+	MK_RBUTTON  uint16 = 0x0002
+	MK_SHIFT    uint16 = 0x0004
+	MK_CONTROL  uint16 = 0x0008
+	MK_MBUTTON  uint16 = 0x0010
+	MK_XBUTTON1 uint16 = 0x0020
+	MK_XBUTTON2 uint16 = 0x0040
+)
+
+// WHEEL_DELTA is the wheel delta of one notch.
+const WHEEL_DELTA int16 = 120
+
+// TRACKMOUSEEVENT is used by TrackMouseEvent to track when the mouse pointer leaves a window
+// or hovers over a window for a specified amount of time.
+type TRACKMOUSEEVENT struct {
+	CbSize      uint32
+	DwFlags     uint32
+	HwndTrack   windows.HWND
+	DwHoverTime uint32
+}
+
+// TrackMouseEvent posts WM_MOUSELEAVE or WM_MOUSEHOVER to tme.HwndTrack as requested by tme.DwFlags.
+// CbSize is filled in automatically. Tracking ends after one message is posted, so it is usually
+// requested again from the next WM_MOUSEMOVE.
+func TrackMouseEvent(tme *TRACKMOUSEEVENT) error {
+	tme.CbSize = uint32(unsafe.Sizeof(*tme))
+	r1, _, err := User32.NewProc("TrackMouseEvent").Call(uintptr(unsafe.Pointer(tme)))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// TrackMouseLeave requests a WM_MOUSELEAVE message when the mouse leaves hwnd.
+func TrackMouseLeave(hwnd windows.HWND) error {
+	return TrackMouseEvent(&TRACKMOUSEEVENT{DwFlags: TME_LEAVE, HwndTrack: hwnd})
+}
+
+// TrackMouseHover requests a WM_MOUSEHOVER message when the mouse rests over hwnd for hoverTimeMs,
+// or for the system default hover time if hoverTimeMs is HOVER_DEFAULT.
+func TrackMouseHover(hwnd windows.HWND, hoverTimeMs uint32) error {
+	return TrackMouseEvent(&TRACKMOUSEEVENT{DwFlags: TME_HOVER, HwndTrack: hwnd, DwHoverTime: hoverTimeMs})
+}
+
+// DecodeMouseCoords extracts the cursor position from the lParam of a mouse message,
+// like GET_X_LPARAM and GET_Y_LPARAM. Coordinates can be negative on multi-monitor setups.
+func DecodeMouseCoords(lParam uintptr) (x, y int32) {
+	return int32(int16(lParam)), int32(int16(lParam >> 16))
+}
+
+// DecodeMouseWheel extracts the wheel rotation, in multiples of WHEEL_DELTA, and the MK_* key state
+// from the wParam of WM_MOUSEWHEEL or WM_MOUSEHWHEEL.
+func DecodeMouseWheel(wParam uintptr) (delta int16, keys uint16) {
+	return int16(wParam >> 16), uint16(wParam)
+}
+
+// DecodeMouseButtons extracts the MK_* key state from the wParam of a mouse message.
+func DecodeMouseButtons(wParam uintptr) (keys uint16) {
+	return uint16(wParam)
+}
+
+// GetDoubleClickTime retrieves the maximum number of milliseconds between the two clicks of a double-click.
+func GetDoubleClickTime() uint32 {
+	r1, _, _ := User32.NewProc("GetDoubleClickTime").Call()
+	return uint32(r1)
+}
+
+// IsDoubleClick reports whether a click at (x2, y2) at time t2 completes a double-click started
+// by a click at (x1, y1) at time t1. Times are message times in milliseconds, as in MSG.Time.
+// The second click must come within GetDoubleClickTime and inside the SM_CXDOUBLECLK by
+// SM_CYDOUBLECLK rectangle centered on the first click.
+func IsDoubleClick(x1, y1, x2, y2 int32, t1, t2 uint32) bool {
+	// Unsigned subtraction handles the wrap-around of the message clock.
+	if t2-t1 > GetDoubleClickTime() {
+		return false
+	}
+
+	dx, dy := x2-x1, y2-y1
+	if dx < 0 {
+		dx = -dx
+	}
+	if dy < 0 {
+		dy = -dy
+	}
+	return dx <= GetSystemMetrics(SM_CXDOUBLECLK)/2 && dy <= GetSystemMetrics(SM_CYDOUBLECLK)/2
+}
+
+// GetCursorPos retrieves the position of the mouse cursor in screen coordinates.
+func GetCursorPos() (POINT, error) {
+	var pt POINT
+	r1, _, err := User32.NewProc("GetCursorPos").Call(uintptr(unsafe.Pointer(&pt)))
+	if r1 == 0 {
+		return POINT{}, err
+	}
+	return pt, nil
+}
+
+// SetCursorPos moves the mouse cursor to (x, y) in screen coordinates.
+func SetCursorPos(x, y int32) error {
+	r1, _, err := User32.NewProc("SetCursorPos").Call(uintptr(x), uintptr(y))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package win32utils
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"golang.org/x/sys/windows"
+)
+
+func TestDecodeMouseCoords(t *testing.T) {
+	tests := []struct {
+		lParam uintptr
+		x, y   int32
+	}{
+		{0x00500032, 50, 80},
+		{0xFFFEFFFF, -1, -2},
+	}
+	for _, tt := range tests {
+		if x, y := DecodeMouseCoords(tt.lParam); x != tt.x || y != tt.y {
+			t.Errorf("DecodeMouseCoords(0x%X) = %d, %d, want %d, %d", tt.lParam, x, y, tt.x, tt.y)
+		}
+	}
+}
+
+func TestDecodeMouseWheel(t *testing.T) {
+	delta := -WHEEL_DELTA
+	wParam := uintptr(uint16(delta))<<16 | uintptr(MK_CONTROL)
+	gotDelta, keys := DecodeMouseWheel(wParam)
+	if gotDelta != delta || keys != MK_CONTROL {
+		t.Errorf("DecodeMouseWheel(0x%X) = %d, 0x%X, want %d, 0x%X", wParam, gotDelta, keys, delta, MK_CONTROL)
+	}
+}
+
+func TestGetDoubleClickTime(t *testing.T) {
+	if ms := GetDoubleClickTime(); ms < 100 || ms > 5000 {
+		t.Errorf("GetDoubleClickTime() = %d, want between 100 and 5000", ms)
+	}
+}
+
+func TestSetCursorPos(t *testing.T) {
+	pt, err := GetCursorPos()
+	if err != nil {
+		t.Skipf("GetCursorPos() error = %v; the test needs an interactive desktop", err)
+	}
+	defer SetCursorPos(pt.X, pt.Y)
+
+	if err := SetCursorPos(10, 20); err != nil {
+		t.Fatalf("SetCursorPos() error = %v", err)
+	}
+	if got, _ := GetCursorPos(); got != (POINT{10, 20}) {
+		t.Errorf("GetCursorPos() after SetCursorPos = %v, want %v", got, POINT{10, 20})
+	}
+}
+
+func TestTrackMouseLeave(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	var trackErr error
+	var left bool
+	hwnd, err := createManagedWindow("win32utils_test_mouseleave", 0, WS_POPUP|WS_VISIBLE, 0,
+		func(hwnd windows.HWND, msg uint32, wParam, lParam uintptr) uintptr {
+			switch msg {
+			case WM_MOUSEMOVE:
+				trackErr = TrackMouseLeave(hwnd)
+				return 0
+			case WM_MOUSELEAVE:
+				left = true
+				return 0
+			}
+			return DefWindowProcW(hwnd, msg, wParam, lParam)
+		})
+	if err != nil {
+		t.Fatalf("createManagedWindow() error = %v", err)
+	}
+	defer DestroyWindow(hwnd)
+
+	// Keep the window away from the cursor, so that the system posts WM_MOUSELEAVE
+	// as soon as tracking starts.
+	pt, err := GetCursorPos()
+	if err != nil {
+		t.Skipf("GetCursorPos() error = %v; the test needs an interactive desktop", err)
+	}
+	x := int32(0)
+	if pt.X < 100 {
+		x = pt.X + 100
+	}
+	y := int32(0)
+	if pt.Y < 100 {
+		y = pt.Y + 100
+	}
+	if err := MoveWindow(hwnd, x, y, 20, 20, false); err != nil {
+		t.Fatalf("MoveWindow() error = %v", err)
+	}
+
+	if err := PostMessageW(hwnd, WM_MOUSEMOVE, 0, 0); err != nil {
+		t.Fatalf("PostMessageW() error = %v", err)
+	}
+	var msg MSG
+	for deadline := time.Now().Add(time.Second); !left && time.Now().Before(deadline); {
+		if PeekMessageW(&msg, hwnd, 0, 0, PM_REMOVE) {
+			DispatchMessageW(&msg)
+		} else {
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	if trackErr != nil {
+		t.Fatalf("TrackMouseLeave() error = %v", trackErr)
+	}
+	if !left {
+		t.Error("WndProc did not receive WM_MOUSELEAVE")
+	}
+}
@@ -0,0 +1,104 @@
+package win32utils
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Access rights for OpenNamedMutex.
+const (
+	MUTEX_MODIFY_STATE uint32 = 0x0001
+	SYNCHRONIZE        uint32 = 0x00100000
+)
+
+// CreateNamedMutex creates the mutex name, or opens it if it already exists, without taking ownership.
+// created reports whether this call created it, that is, whether the caller is the first instance.
+// Prefix name with `Global\` to detect instances in other sessions.
+func CreateNamedMutex(name string) (handle windows.Handle, created bool, err error) {
+	namePtr, err := windows.UTF16PtrFromString(name)
+	if err != nil {
+		return 0, false, err
+	}
+	r1, _, err := Kernel32.NewProc("CreateMutexW").Call(0, 0, uintptr(unsafe.Pointer(namePtr)))
+	if r1 == 0 {
+		return 0, false, err
+	}
+	return windows.Handle(r1), err != windows.ERROR_ALREADY_EXISTS, nil
+}
+
+// OpenNamedMutex opens the existing mutex name with SYNCHRONIZE and MUTEX_MODIFY_STATE access.
+func OpenNamedMutex(name string) (windows.Handle, error) {
+	namePtr, err := windows.UTF16PtrFromString(name)
+	if err != nil {
+		return 0, err
+	}
+	r1, _, err := Kernel32.NewProc("OpenMutexW").Call(uintptr(SYNCHRONIZE|MUTEX_MODIFY_STATE), 0, uintptr(unsafe.Pointer(namePtr)))
+	if r1 == 0 {
+		return 0, err
+	}
+	return windows.Handle(r1), nil
+}
+
+// ReleaseMutex releases ownership of the mutex h, which the calling thread must own.
+func ReleaseMutex(h windows.Handle) error {
+	r1, _, err := Kernel32.NewProc("ReleaseMutex").Call(uintptr(h))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// IsSingleInstance creates the mutex name and reports whether no other instance holds it.
+// The handle must stay open for the lifetime of the instance; close it with CloseHandle.
+func IsSingleInstance(name string) (bool, windows.Handle, error) {
+	h, created, err := CreateNamedMutex(name)
+	if err != nil {
+		return false, 0, err
+	}
+	return created, h, nil
+}
+
+// CreateEventW creates the event object name, or opens it if it already exists. An empty name
+// creates an unnamed event. A manual-reset event stays signaled until ResetEvent; an auto-reset
+// event is reset when a single waiting thread is released.
+func CreateEventW(name string, manualReset, initialState bool) (windows.Handle, error) {
+	var namePtr *uint16
+	if name != "" {
+		var err error
+		namePtr, err = windows.UTF16PtrFromString(name)
+		if err != nil {
+			return 0, err
+		}
+	}
+	var bManualReset, bInitialState uintptr
+	if manualReset {
+		bManualReset = 1
+	}
+	if initialState {
+		bInitialState = 1
+	}
+	r1, _, err := Kernel32.NewProc("CreateEventW").Call(0, bManualReset, bInitialState, uintptr(unsafe.Pointer(namePtr)))
+	if r1 == 0 {
+		return 0, err
+	}
+	return windows.Handle(r1), nil
+}
+
+// SetEvent sets the event h to the signaled state.
+func SetEvent(h windows.Handle) error {
+	r1, _, err := Kernel32.NewProc("SetEvent").Call(uintptr(h))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// ResetEvent sets the event h to the nonsignaled state.
+func ResetEvent(h windows.Handle) error {
+	r1, _, err := Kernel32.NewProc("ResetEvent").Call(uintptr(h))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
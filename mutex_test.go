@@ -0,0 +1,57 @@
+package win32utils
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+
+	"golang.org/x/sys/windows"
+)
+
+func TestCreateNamedMutex(t *testing.T) {
+	name := fmt.Sprintf("win32utils-test-mutex-%d", os.Getpid())
+
+	var (
+		wg      sync.WaitGroup
+		handles [2]windows.Handle
+		created [2]bool
+		errs    [2]error
+	)
+	for i := range handles {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			handles[i], created[i], errs[i] = CreateNamedMutex(name)
+		}(i)
+	}
+	wg.Wait()
+	for i, h := range handles {
+		if errs[i] != nil {
+			t.Fatalf("CreateNamedMutex() error = %v", errs[i])
+		}
+		defer CloseHandle(h)
+	}
+	if created[0] == created[1] {
+		t.Errorf("CreateNamedMutex() created = %v, want exactly one true", created)
+	}
+
+	h, err := OpenNamedMutex(name)
+	if err != nil {
+		t.Fatalf("OpenNamedMutex() error = %v", err)
+	}
+	CloseHandle(h)
+
+	if single, h, err := IsSingleInstance(name); err != nil || single {
+		t.Errorf("IsSingleInstance() = %v, %v, want false, nil", single, err)
+	} else {
+		CloseHandle(h)
+	}
+}
+
+func TestOpenNamedMutexNotFound(t *testing.T) {
+	_, err := OpenNamedMutex(fmt.Sprintf("win32utils-test-missing-%d", os.Getpid()))
+	if err != windows.ERROR_FILE_NOT_FOUND {
+		t.Errorf("OpenNamedMutex() error = %v, want %v", err, windows.ERROR_FILE_NOT_FOUND)
+	}
+}
@@ -0,0 +1,70 @@
+package win32utils
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Operational states reported in AdapterInfo.OperStatus.
+const (
+	IF_OPER_STATUS_UP               uint32 = 1
+	IF_OPER_STATUS_DOWN             uint32 = 2
+	IF_OPER_STATUS_TESTING          uint32 = 3
+	IF_OPER_STATUS_UNKNOWN          uint32 = 4
+	IF_OPER_STATUS_DORMANT          uint32 = 5
+	IF_OPER_STATUS_NOT_PRESENT      uint32 = 6
+	IF_OPER_STATUS_LOWER_LAYER_DOWN uint32 = 7
+)
+
+// AdapterInfo describes a network adapter and its unicast IP addresses.
+type AdapterInfo struct {
+	Name             string
+	FriendlyName     string
+	UnicastAddresses []string
+	Flags            uint32
+	OperStatus       uint32
+}
+
+// GetAdaptersAddresses lists the network adapters of the local computer with both their IPv4 and IPv6 addresses.
+func GetAdaptersAddresses() ([]AdapterInfo, error) {
+	var size uint32
+	err := windows.GetAdaptersAddresses(windows.AF_UNSPEC, 0, 0, nil, &size)
+	if err != nil && err != windows.ERROR_BUFFER_OVERFLOW {
+		return nil, err
+	}
+
+	var buf []byte
+	for {
+		if size == 0 {
+			return nil, nil
+		}
+		buf = make([]byte, size)
+		err = windows.GetAdaptersAddresses(windows.AF_UNSPEC, 0, 0,
+			(*windows.IpAdapterAddresses)(unsafe.Pointer(&buf[0])), &size)
+		if err == nil {
+			break
+		}
+		// The adapter list can grow between the two calls.
+		if err != windows.ERROR_BUFFER_OVERFLOW {
+			return nil, err
+		}
+	}
+
+	var adapters []AdapterInfo
+	for aa := (*windows.IpAdapterAddresses)(unsafe.Pointer(&buf[0])); aa != nil; aa = aa.Next {
+		info := AdapterInfo{
+			Name:         windows.BytePtrToString(aa.AdapterName),
+			FriendlyName: windows.UTF16PtrToString(aa.FriendlyName),
+			Flags:        aa.Flags,
+			OperStatus:   aa.OperStatus,
+		}
+		for ua := aa.FirstUnicastAddress; ua != nil; ua = ua.Next {
+			if ip := ua.Address.IP(); ip != nil {
+				info.UnicastAddresses = append(info.UnicastAddresses, ip.String())
+			}
+		}
+		adapters = append(adapters, info)
+	}
+	return adapters, nil
+}
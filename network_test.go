@@ -0,0 +1,23 @@
+package win32utils
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGetAdaptersAddresses(t *testing.T) {
+	adapters, err := GetAdaptersAddresses()
+	if err != nil {
+		t.Fatalf("GetAdaptersAddresses() error = %v", err)
+	}
+	if len(adapters) == 0 {
+		t.Fatal("GetAdaptersAddresses() returned no adapters, want at least the loopback adapter")
+	}
+
+	for _, a := range adapters {
+		if slices.Contains(a.UnicastAddresses, "127.0.0.1") || slices.Contains(a.UnicastAddresses, "::1") {
+			return
+		}
+	}
+	t.Errorf("GetAdaptersAddresses() = %+v, want an adapter with a loopback address", adapters)
+}
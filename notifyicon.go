@@ -0,0 +1,113 @@
+package win32utils
+
+import (
+	"errors"
+	"unicode/utf16"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Messages for ShellNotifyIconW.
+const (
+	NIM_ADD        uint32 = 0x00000000
+	NIM_MODIFY     uint32 = 0x00000001
+	NIM_DELETE     uint32 = 0x00000002
+	NIM_SETFOCUS   uint32 = 0x00000003
+	NIM_SETVERSION uint32 = 0x00000004
+)
+
+// Flags for the UFlags member of NOTIFYICONDATAW, selecting the members that are valid.
+const (
+	NIF_MESSAGE  uint32 = 0x00000001
+	NIF_ICON     uint32 = 0x00000002
+	NIF_TIP      uint32 = 0x00000004
+	NIF_STATE    uint32 = 0x00000008
+	NIF_INFO     uint32 = 0x00000010
+	NIF_GUID     uint32 = 0x00000020
+	NIF_REALTIME uint32 = 0x00000040
+	NIF_SHOWTIP  uint32 = 0x00000080
+)
+
+// Notification area icon states for NOTIFYICONDATAW, used with NIF_STATE.
+const (
+	NIS_HIDDEN     uint32 = 0x00000001
+	NIS_SHAREDICON uint32 = 0x00000002
+)
+
+// Balloon notification flags for the DwInfoFlags member of NOTIFYICONDATAW.
+const (
+	NIIF_NONE               uint32 = 0x00000000
+	NIIF_INFO               uint32 = 0x00000001
+	NIIF_WARNING            uint32 = 0x00000002
+	NIIF_ERROR              uint32 = 0x00000003
+	NIIF_USER               uint32 = 0x00000004
+	NIIF_NOSOUND            uint32 = 0x00000010
+	NIIF_LARGE_ICON         uint32 = 0x00000020
+	NIIF_RESPECT_QUIET_TIME uint32 = 0x00000080
+)
+
+// NOTIFYICONDATAW contains information that the system needs to display notifications in the notification area.
+type NOTIFYICONDATAW struct {
+	CbSize            uint32
+	HWnd              windows.HWND
+	UID               uint32
+	UFlags            uint32
+	UCallbackMessage  uint32
+	HIcon             windows.Handle
+	SzTip             [128]uint16
+	DwState           uint32
+	DwStateMask       uint32
+	SzInfo            [256]uint16
+	UTimeoutOrVersion uint32
+	SzInfoTitle       [64]uint16
+	DwInfoFlags       uint32
+	GuidItem          windows.GUID
+	HBalloonIcon      windows.Handle
+}
+
+var errShellNotifyIcon = errors.New("win32utils: Shell_NotifyIconW failed")
+
+// ShellNotifyIconW sends a message to the notification area. data.CbSize is filled in automatically.
+func ShellNotifyIconW(message uint32, data *NOTIFYICONDATAW) error {
+	data.CbSize = uint32(unsafe.Sizeof(*data))
+	r1, _, err := Shell32.NewProc("Shell_NotifyIconW").Call(uintptr(message), uintptr(unsafe.Pointer(data)))
+	if r1 == 0 {
+		// Shell_NotifyIconW often fails without setting a last error.
+		if err == windows.ERROR_SUCCESS {
+			return errShellNotifyIcon
+		}
+		return err
+	}
+	return nil
+}
+
+// SetBalloon fills the balloon members of d and sets NIF_INFO. title is truncated to 63 and info
+// to 255 UTF-16 code units. iconType is one of the NIIF_ values, optionally combined with flags such
+// as NIIF_NOSOUND. timeout is in milliseconds and is ignored by Windows Vista and later.
+func (d *NOTIFYICONDATAW) SetBalloon(title, info string, iconType uint32, timeout uint32) {
+	d.UFlags |= NIF_INFO
+	copyUTF16(d.SzInfoTitle[:], title)
+	copyUTF16(d.SzInfo[:], info)
+	d.DwInfoFlags = iconType
+	d.UTimeoutOrVersion = timeout
+}
+
+// ShowBalloon shows a balloon notification on the existing notification area icon identified by hwnd and uid.
+func ShowBalloon(hwnd windows.HWND, uid uint32, title, info string, iconType uint32, timeout uint32) error {
+	data := NOTIFYICONDATAW{HWnd: hwnd, UID: uid}
+	data.SetBalloon(title, info, iconType, timeout)
+	return ShellNotifyIconW(NIM_MODIFY, &data)
+}
+
+// copyUTF16 copies s into dst as a NUL-terminated UTF-16 string, truncating it to fit
+// without splitting a surrogate pair.
+func copyUTF16(dst []uint16, s string) {
+	u := utf16.Encode([]rune(s))
+	n := min(len(u), len(dst)-1)
+	if n > 0 && n < len(u) && utf16.IsSurrogate(rune(u[n-1])) && u[n-1] < 0xDC00 {
+		n--
+	}
+	copy(dst, u[:n])
+	clear(dst[n:])
+}
@@ -0,0 +1,33 @@
+package win32utils
+
+import (
+	"strings"
+	"testing"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+func TestShellNotifyIconWFillsCbSize(t *testing.T) {
+	var data NOTIFYICONDATAW
+	// There is no icon with this window and ID, so the call itself fails.
+	_ = ShellNotifyIconW(NIM_MODIFY, &data)
+	if want := uint32(unsafe.Sizeof(data)); data.CbSize != want {
+		t.Errorf("CbSize = %d, want %d", data.CbSize, want)
+	}
+}
+
+func TestSetBalloonTruncatesTitle(t *testing.T) {
+	var data NOTIFYICONDATAW
+	data.SetBalloon(strings.Repeat("t", 100), "info", NIIF_WARNING|NIIF_NOSOUND, 0)
+
+	if got := windows.UTF16ToString(data.SzInfoTitle[:]); got != strings.Repeat("t", 63) {
+		t.Errorf("SzInfoTitle has %d characters, want 63", len(got))
+	}
+	if got := windows.UTF16ToString(data.SzInfo[:]); got != "info" {
+		t.Errorf("SzInfo = %q, want %q", got, "info")
+	}
+	if data.UFlags&NIF_INFO == 0 || data.DwInfoFlags != NIIF_WARNING|NIIF_NOSOUND {
+		t.Errorf("UFlags = 0x%X, DwInfoFlags = 0x%X, want NIF_INFO and NIIF_WARNING|NIIF_NOSOUND", data.UFlags, data.DwInfoFlags)
+	}
+}
@@ -0,0 +1,119 @@
+package win32utils
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// SYSTEM_INFORMATION_CLASS values for NtQuerySystemInformation.
+const (
+	systemBasicInformationClass                int32 = 0x00
+	systemPerformanceInformationClass          int32 = 0x02
+	systemProcessorPerformanceInformationClass int32 = 0x08
+)
+
+// SystemProcessorPerformanceInformation holds the cumulative times of one processor, in 100-nanosecond units.
+// KernelTime includes IdleTime.
+type SystemProcessorPerformanceInformation struct {
+	IdleTime       int64
+	KernelTime     int64
+	UserTime       int64
+	DpcTime        int64
+	InterruptTime  int64
+	InterruptCount uint32
+}
+
+// SystemMemoryInformation summarizes physical memory and commit charge. Page counts are in pages of PageSize bytes.
+type SystemMemoryInformation struct {
+	PageSize              uint32
+	NumberOfPhysicalPages uint32
+	AvailablePages        uint32
+	CommittedPages        uintptr
+	CommitLimit           uintptr
+	PeakCommitment        uintptr
+	PageFaultCount        uint32
+}
+
+type systemBasicInformation struct {
+	Reserved                     uint32
+	TimerResolution              uint32
+	PageSize                     uint32
+	NumberOfPhysicalPages        uint32
+	LowestPhysicalPageNumber     uint32
+	HighestPhysicalPageNumber    uint32
+	AllocationGranularity        uint32
+	MinimumUserModeAddress       uintptr
+	MaximumUserModeAddress       uintptr
+	ActiveProcessorsAffinityMask uintptr
+	NumberOfProcessors           int8
+}
+
+// systemPerformanceInformation is the stable leading part of SYSTEM_PERFORMANCE_INFORMATION.
+type systemPerformanceInformation struct {
+	IdleProcessTime       int64
+	IoReadTransferCount   int64
+	IoWriteTransferCount  int64
+	IoOtherTransferCount  int64
+	IoReadOperationCount  uint32
+	IoWriteOperationCount uint32
+	IoOtherOperationCount uint32
+	AvailablePages        uint32
+	CommittedPages        uintptr
+	CommitLimit           uintptr
+	PeakCommitment        uintptr
+	PageFaultCount        uint32
+}
+
+// NtQuerySystemProcessorPerformanceInformation retrieves the performance times of every processor.
+// CPU usage is derived by sampling twice and comparing the deltas.
+// It uses the undocumented NtQuerySystemInformation, whose behavior may change between Windows versions.
+func NtQuerySystemProcessorPerformanceInformation() ([]SystemProcessorPerformanceInformation, error) {
+	infos := make([]SystemProcessorPerformanceInformation, GetSystemInfo().NumberOfProcessors)
+	for {
+		size := uint32(uintptr(len(infos)) * unsafe.Sizeof(infos[0]))
+		var returned uint32
+		r1, _, _ := Ntdll.NewProc("NtQuerySystemInformation").Call(uintptr(systemProcessorPerformanceInformationClass),
+			uintptr(unsafe.Pointer(&infos[0])), uintptr(size), uintptr(unsafe.Pointer(&returned)))
+		status := windows.NTStatus(r1)
+		if status == windows.STATUS_INFO_LENGTH_MISMATCH {
+			infos = make([]SystemProcessorPerformanceInformation, len(infos)*2)
+			continue
+		}
+		if status != windows.STATUS_SUCCESS {
+			return nil, status
+		}
+		return infos[:uintptr(returned)/unsafe.Sizeof(infos[0])], nil
+	}
+}
+
+// NtQuerySystemMemoryInformation retrieves physical memory, commit charge and page fault counters.
+// It uses the undocumented NtQuerySystemInformation, whose behavior may change between Windows versions.
+func NtQuerySystemMemoryInformation() (SystemMemoryInformation, error) {
+	var basic systemBasicInformation
+	r1, _, _ := Ntdll.NewProc("NtQuerySystemInformation").Call(uintptr(systemBasicInformationClass),
+		uintptr(unsafe.Pointer(&basic)), unsafe.Sizeof(basic), 0)
+	if status := windows.NTStatus(r1); status != windows.STATUS_SUCCESS {
+		return SystemMemoryInformation{}, status
+	}
+
+	// SYSTEM_PERFORMANCE_INFORMATION has grown over Windows releases and must be
+	// queried with at least its full size, so read it into a generous buffer.
+	buf := make([]byte, 4096)
+	r1, _, _ = Ntdll.NewProc("NtQuerySystemInformation").Call(uintptr(systemPerformanceInformationClass),
+		uintptr(unsafe.Pointer(&buf[0])), uintptr(len(buf)), 0)
+	if status := windows.NTStatus(r1); status != windows.STATUS_SUCCESS {
+		return SystemMemoryInformation{}, status
+	}
+	perf := (*systemPerformanceInformation)(unsafe.Pointer(&buf[0]))
+
+	return SystemMemoryInformation{
+		PageSize:              basic.PageSize,
+		NumberOfPhysicalPages: basic.NumberOfPhysicalPages,
+		AvailablePages:        perf.AvailablePages,
+		CommittedPages:        perf.CommittedPages,
+		CommitLimit:           perf.CommitLimit,
+		PeakCommitment:        perf.PeakCommitment,
+		PageFaultCount:        perf.PageFaultCount,
+	}, nil
+}
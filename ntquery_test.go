@@ -0,0 +1,23 @@
+package win32utils
+
+import "testing"
+
+func TestNtQuerySystemProcessorPerformanceInformation(t *testing.T) {
+	infos, err := NtQuerySystemProcessorPerformanceInformation()
+	if err != nil {
+		t.Fatalf("NtQuerySystemProcessorPerformanceInformation() error = %v", err)
+	}
+	if want := GetSystemInfo().NumberOfProcessors; uint32(len(infos)) != want {
+		t.Errorf("len(NtQuerySystemProcessorPerformanceInformation()) = %d, want %d", len(infos), want)
+	}
+}
+
+func TestNtQuerySystemMemoryInformation(t *testing.T) {
+	info, err := NtQuerySystemMemoryInformation()
+	if err != nil {
+		t.Fatalf("NtQuerySystemMemoryInformation() error = %v", err)
+	}
+	if info.PageSize == 0 || info.AvailablePages > info.NumberOfPhysicalPages {
+		t.Errorf("NtQuerySystemMemoryInformation() = %+v, want a non-zero page size and available <= physical pages", info)
+	}
+}
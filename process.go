@@ -0,0 +1,69 @@
+package win32utils
+
+import (
+	"path/filepath"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// ProcessInfo identifies a running process.
+type ProcessInfo struct {
+	PID uint32
+	// Name is the executable file name, or empty if the process could not be opened.
+	Name string
+}
+
+// EnumProcesses retrieves the IDs of all running processes.
+func EnumProcesses() ([]uint32, error) {
+	pids := make([]uint32, 1024)
+	for {
+		size := uint32(len(pids)) * 4
+		var needed uint32
+		r1, _, err := Psapi.NewProc("EnumProcesses").Call(uintptr(unsafe.Pointer(&pids[0])), uintptr(size), uintptr(unsafe.Pointer(&needed)))
+		if r1 == 0 {
+			return nil, err
+		}
+		// A completely filled buffer may have been too small.
+		if needed < size {
+			return pids[:needed/4], nil
+		}
+		pids = make([]uint32, len(pids)*2)
+	}
+}
+
+// GetProcessImageFileNameW retrieves the path of the executable of process pid, in device form
+// such as \Device\HarddiskVolume1\Windows\notepad.exe.
+func GetProcessImageFileNameW(pid uint32) (string, error) {
+	process, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, pid)
+	if err != nil {
+		return "", err
+	}
+	defer windows.CloseHandle(process)
+
+	buf := make([]uint16, windows.MAX_LONG_PATH)
+	r1, _, err := Psapi.NewProc("GetProcessImageFileNameW").Call(uintptr(process), uintptr(unsafe.Pointer(&buf[0])), uintptr(len(buf)))
+	if r1 == 0 {
+		return "", err
+	}
+	return windows.UTF16ToString(buf[:r1]), nil
+}
+
+// ListRunningProcesses lists all running processes with their executable names.
+// Processes that cannot be opened, such as protected system processes, have an empty Name.
+func ListRunningProcesses() ([]ProcessInfo, error) {
+	pids, err := EnumProcesses()
+	if err != nil {
+		return nil, err
+	}
+
+	processes := make([]ProcessInfo, 0, len(pids))
+	for _, pid := range pids {
+		info := ProcessInfo{PID: pid}
+		if path, err := GetProcessImageFileNameW(pid); err == nil {
+			info.Name = filepath.Base(path)
+		}
+		processes = append(processes, info)
+	}
+	return processes, nil
+}
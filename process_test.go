@@ -0,0 +1,22 @@
+package win32utils
+
+import (
+	"os"
+	"testing"
+)
+
+func TestListRunningProcesses(t *testing.T) {
+	processes, err := ListRunningProcesses()
+	if err != nil {
+		t.Fatalf("ListRunningProcesses() error = %v", err)
+	}
+	for _, p := range processes {
+		if p.PID == uint32(os.Getpid()) {
+			if p.Name == "" {
+				t.Errorf("ListRunningProcesses() has no name for the current process")
+			}
+			return
+		}
+	}
+	t.Errorf("ListRunningProcesses() does not contain the current process %d", os.Getpid())
+}
@@ -0,0 +1,112 @@
+package win32utils
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Raw input device types for RAWINPUTHEADER.DwType.
+const (
+	RIM_TYPEMOUSE    uint32 = 0
+	RIM_TYPEKEYBOARD uint32 = 1
+	RIM_TYPEHID      uint32 = 2
+)
+
+// Commands for GetRawInputData.
+const (
+	RID_INPUT  uint32 = 0x10000003
+	RID_HEADER uint32 = 0x10000005
+)
+
+// RAWINPUTHEADER contains the header information that is part of the raw input data.
+type RAWINPUTHEADER struct {
+	DwType  uint32
+	DwSize  uint32
+	HDevice windows.Handle
+	WParam  uintptr
+}
+
+// RAWMOUSE contains information about the state of the mouse.
+type RAWMOUSE struct {
+	UsFlags            uint16
+	_                  uint16
+	UsButtonFlags      uint16
+	UsButtonData       uint16
+	UlRawButtons       uint32
+	LLastX             int32
+	LLastY             int32
+	UlExtraInformation uint32
+}
+
+// RAWKEYBOARD contains information about the state of the keyboard.
+type RAWKEYBOARD struct {
+	MakeCode         uint16
+	Flags            uint16
+	Reserved         uint16
+	VKey             uint16
+	Message          uint32
+	ExtraInformation uint32
+}
+
+// RAWINPUT contains the raw input from a device. Data holds a RAWMOUSE, RAWKEYBOARD or
+// the start of a RAWHID depending on Header.DwType; HID reports longer than Data are truncated.
+type RAWINPUT struct {
+	Header RAWINPUTHEADER
+	Data   [24]byte
+}
+
+// Mouse returns the data of a RIM_TYPEMOUSE input.
+func (r *RAWINPUT) Mouse() *RAWMOUSE {
+	return (*RAWMOUSE)(unsafe.Pointer(&r.Data))
+}
+
+// Keyboard returns the data of a RIM_TYPEKEYBOARD input.
+func (r *RAWINPUT) Keyboard() *RAWKEYBOARD {
+	return (*RAWKEYBOARD)(unsafe.Pointer(&r.Data))
+}
+
+// readRawInput is a variable so that tests can feed synthetic WM_INPUT messages.
+var readRawInput = getRawInputData
+
+// getRawInputData reads the RAWINPUT behind the HRAWINPUT passed in the lParam of WM_INPUT.
+func getRawInputData(hRawInput uintptr) (RAWINPUT, error) {
+	headerSize := unsafe.Sizeof(RAWINPUTHEADER{})
+	var size uint32
+	r1, _, err := User32.NewProc("GetRawInputData").Call(hRawInput, uintptr(RID_INPUT), 0, uintptr(unsafe.Pointer(&size)), headerSize)
+	if int32(r1) == -1 {
+		return RAWINPUT{}, err
+	}
+	if size == 0 {
+		return RAWINPUT{}, windows.ERROR_INVALID_DATA
+	}
+
+	// Use uint64 elements so the buffer is aligned for RAWINPUTHEADER.
+	buf := make([]uint64, (size+7)/8)
+	r1, _, err = User32.NewProc("GetRawInputData").Call(hRawInput, uintptr(RID_INPUT), uintptr(unsafe.Pointer(&buf[0])),
+		uintptr(unsafe.Pointer(&size)), headerSize)
+	if int32(r1) == -1 {
+		return RAWINPUT{}, err
+	}
+
+	var raw RAWINPUT
+	copy(unsafe.Slice((*byte)(unsafe.Pointer(&raw)), unsafe.Sizeof(raw)), unsafe.Slice((*byte)(unsafe.Pointer(&buf[0])), size))
+	return raw, nil
+}
+
+// SetRawInputHandler calls fn with the data of every WM_INPUT that hwnd receives.
+// The devices must be registered with RegisterRawInputDevices for hwnd to receive WM_INPUT.
+// WM_INPUT is not passed on to the window procedure; DefWindowProcW is called instead,
+// which releases the input.
+func SetRawInputHandler(hwnd windows.HWND, fn func(RAWINPUT)) {
+	ChainWndProc(hwnd, func(hwnd windows.HWND, msg uint32, wParam, lParam uintptr) (uintptr, bool) {
+		if msg != WM_INPUT {
+			return 0, false
+		}
+		raw, err := readRawInput(lParam)
+		if err == nil {
+			fn(raw)
+		}
+		return DefWindowProcW(hwnd, msg, wParam, lParam), true
+	})
+}
@@ -0,0 +1,87 @@
+package win32utils
+
+import "fmt"
+
+// These helpers follow the semantics of the User32 functions of the same names
+// but are implemented in Go, since they are pure arithmetic.
+
+// String formats r with field names, for example "{Left:0 Top:0 Right:10 Bottom:10}".
+func (r RECT) String() string {
+	return fmt.Sprintf("{Left:%d Top:%d Right:%d Bottom:%d}", r.Left, r.Top, r.Right, r.Bottom)
+}
+
+// Width returns Right - Left.
+func (r RECT) Width() int32 {
+	return r.Right - r.Left
+}
+
+// Height returns Bottom - Top.
+func (r RECT) Height() int32 {
+	return r.Bottom - r.Top
+}
+
+// IsEmpty reports whether r has no area, like IsRectEmpty.
+func (r RECT) IsEmpty() bool {
+	return r.Right <= r.Left || r.Bottom <= r.Top
+}
+
+// Contains reports whether the point (x, y) lies within r, like PtInRect.
+func (r RECT) Contains(x, y int32) bool {
+	return PtInRect(r, x, y)
+}
+
+// String formats p with field names, for example "{X:1 Y:2}".
+func (p POINT) String() string {
+	return fmt.Sprintf("{X:%d Y:%d}", p.X, p.Y)
+}
+
+// IntersectRect returns the intersection of r1 and r2.
+// If they do not overlap, it returns an empty RECT and false.
+func IntersectRect(r1, r2 RECT) (RECT, bool) {
+	r := RECT{
+		Left:   max(r1.Left, r2.Left),
+		Top:    max(r1.Top, r2.Top),
+		Right:  min(r1.Right, r2.Right),
+		Bottom: min(r1.Bottom, r2.Bottom),
+	}
+	if r.IsEmpty() {
+		return RECT{}, false
+	}
+	return r, true
+}
+
+// UnionRect returns the smallest rectangle that contains both r1 and r2.
+// Empty rectangles are ignored.
+func UnionRect(r1, r2 RECT) RECT {
+	switch {
+	case r1.IsEmpty() && r2.IsEmpty():
+		return RECT{}
+	case r1.IsEmpty():
+		return r2
+	case r2.IsEmpty():
+		return r1
+	}
+	return RECT{
+		Left:   min(r1.Left, r2.Left),
+		Top:    min(r1.Top, r2.Top),
+		Right:  max(r1.Right, r2.Right),
+		Bottom: max(r1.Bottom, r2.Bottom),
+	}
+}
+
+// InflateRect grows r by dx on the left and right and by dy on the top and bottom.
+// Negative values shrink it.
+func InflateRect(r RECT, dx, dy int32) RECT {
+	return RECT{Left: r.Left - dx, Top: r.Top - dy, Right: r.Right + dx, Bottom: r.Bottom + dy}
+}
+
+// OffsetRect moves r by dx horizontally and dy vertically.
+func OffsetRect(r RECT, dx, dy int32) RECT {
+	return RECT{Left: r.Left + dx, Top: r.Top + dy, Right: r.Right + dx, Bottom: r.Bottom + dy}
+}
+
+// PtInRect reports whether the point (x, y) lies within r.
+// Points on the right or bottom edge are outside, as in PtInRect.
+func PtInRect(r RECT, x, y int32) bool {
+	return x >= r.Left && x < r.Right && y >= r.Top && y < r.Bottom
+}
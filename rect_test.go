@@ -0,0 +1,64 @@
+package win32utils
+
+import "testing"
+
+func TestIntersectRect(t *testing.T) {
+	tests := []struct {
+		name   string
+		r1, r2 RECT
+		want   RECT
+		ok     bool
+	}{
+		{"disjoint", RECT{0, 0, 10, 10}, RECT{20, 20, 30, 30}, RECT{}, false},
+		{"touching", RECT{0, 0, 10, 10}, RECT{10, 0, 20, 10}, RECT{}, false},
+		{"overlapping", RECT{0, 0, 10, 10}, RECT{5, 5, 15, 15}, RECT{5, 5, 10, 10}, true},
+		{"contained", RECT{0, 0, 100, 100}, RECT{10, 20, 30, 40}, RECT{10, 20, 30, 40}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := IntersectRect(tt.r1, tt.r2)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("IntersectRect(%v, %v) = %v, %v, want %v, %v", tt.r1, tt.r2, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestUnionRect(t *testing.T) {
+	got := UnionRect(RECT{0, 0, 10, 10}, RECT{5, -5, 20, 8})
+	if want := (RECT{0, -5, 20, 10}); got != want {
+		t.Errorf("UnionRect() = %v, want %v", got, want)
+	}
+	if got := UnionRect(RECT{}, RECT{1, 2, 3, 4}); got != (RECT{1, 2, 3, 4}) {
+		t.Errorf("UnionRect() with empty rect = %v, want {1 2 3 4}", got)
+	}
+}
+
+func TestPtInRect(t *testing.T) {
+	r := RECT{0, 0, 10, 10}
+	if !PtInRect(r, 0, 0) {
+		t.Error("PtInRect() top-left corner = false, want true")
+	}
+	if PtInRect(r, 10, 5) {
+		t.Error("PtInRect() right edge = true, want false")
+	}
+}
+
+func TestRectString(t *testing.T) {
+	r := RECT{100, 200, 400, 600}
+	if got, want := r.String(), "{Left:100 Top:200 Right:400 Bottom:600}"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got, want := (POINT{100, 200}).String(), "{X:100 Y:200}"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if r.Width() != 300 || r.Height() != 400 {
+		t.Errorf("Width(), Height() = %d, %d, want 300, 400", r.Width(), r.Height())
+	}
+	if r.IsEmpty() || !(RECT{5, 5, 5, 10}).IsEmpty() {
+		t.Errorf("IsEmpty() is wrong for %v or a zero-width rectangle", r)
+	}
+	if !r.Contains(100, 200) || r.Contains(400, 600) {
+		t.Errorf("Contains() is wrong at the corners of %v", r)
+	}
+}
@@ -0,0 +1,194 @@
+package win32utils
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Predefined registry root keys.
+const (
+	HKEY_CLASSES_ROOT  windows.Handle = 0x80000000
+	HKEY_CURRENT_USER  windows.Handle = 0x80000001
+	HKEY_LOCAL_MACHINE windows.Handle = 0x80000002
+	HKEY_USERS         windows.Handle = 0x80000003
+)
+
+// Registry key access rights.
+const (
+	KEY_QUERY_VALUE uint32 = 0x0001
+	KEY_SET_VALUE   uint32 = 0x0002
+	KEY_READ        uint32 = 0x20019
+	KEY_WRITE       uint32 = 0x20006
+	KEY_ALL_ACCESS  uint32 = 0xF003F
+)
+
+// Registry value types.
+const (
+	REG_SZ        uint32 = 1
+	REG_EXPAND_SZ uint32 = 2
+	REG_BINARY    uint32 = 3
+	REG_DWORD     uint32 = 4
+)
+
+// RegistryKey is an open registry key.
+type RegistryKey struct {
+	Handle windows.Handle
+}
+
+// OpenRegistryKey opens subKey of root, one of the HKEY_ keys or another open key, with the KEY_ access rights.
+func OpenRegistryKey(root windows.Handle, subKey string, access uint32) (*RegistryKey, error) {
+	subKeyPtr, err := windows.UTF16PtrFromString(subKey)
+	if err != nil {
+		return nil, err
+	}
+	var h windows.Handle
+	// The registry functions return the error code instead of setting the last error.
+	r1, _, _ := Advapi32.NewProc("RegOpenKeyExW").Call(uintptr(root), uintptr(unsafe.Pointer(subKeyPtr)), 0,
+		uintptr(access), uintptr(unsafe.Pointer(&h)))
+	if r1 != 0 {
+		return nil, windows.Errno(r1)
+	}
+	return &RegistryKey{Handle: h}, nil
+}
+
+// CreateRegistryKey creates subKey of root, or opens it if it already exists, with KEY_ALL_ACCESS.
+func CreateRegistryKey(root windows.Handle, subKey string) (*RegistryKey, error) {
+	subKeyPtr, err := windows.UTF16PtrFromString(subKey)
+	if err != nil {
+		return nil, err
+	}
+	var h windows.Handle
+	r1, _, _ := Advapi32.NewProc("RegCreateKeyExW").Call(uintptr(root), uintptr(unsafe.Pointer(subKeyPtr)), 0, 0, 0,
+		uintptr(KEY_ALL_ACCESS), 0, uintptr(unsafe.Pointer(&h)), 0)
+	if r1 != 0 {
+		return nil, windows.Errno(r1)
+	}
+	return &RegistryKey{Handle: h}, nil
+}
+
+// RegDeleteKeyW deletes subKey of root and all of its values. subKey must not have subkeys.
+func RegDeleteKeyW(root windows.Handle, subKey string) error {
+	subKeyPtr, err := windows.UTF16PtrFromString(subKey)
+	if err != nil {
+		return err
+	}
+	r1, _, _ := Advapi32.NewProc("RegDeleteKeyW").Call(uintptr(root), uintptr(unsafe.Pointer(subKeyPtr)))
+	if r1 != 0 {
+		return windows.Errno(r1)
+	}
+	return nil
+}
+
+// queryValue reads the value name into buf, or only its type and size if buf is nil.
+func (k *RegistryKey) queryValue(name string, buf []byte) (valueType uint32, size uint32, err error) {
+	namePtr, err := windows.UTF16PtrFromString(name)
+	if err != nil {
+		return 0, 0, err
+	}
+	var data *byte
+	if len(buf) > 0 {
+		data = &buf[0]
+	}
+	size = uint32(len(buf))
+	r1, _, _ := Advapi32.NewProc("RegQueryValueExW").Call(uintptr(k.Handle), uintptr(unsafe.Pointer(namePtr)), 0,
+		uintptr(unsafe.Pointer(&valueType)), uintptr(unsafe.Pointer(data)), uintptr(unsafe.Pointer(&size)))
+	if r1 != 0 {
+		return 0, 0, windows.Errno(r1)
+	}
+	return valueType, size, nil
+}
+
+// setValue writes data as the value name of type valueType.
+func (k *RegistryKey) setValue(name string, valueType uint32, data []byte) error {
+	namePtr, err := windows.UTF16PtrFromString(name)
+	if err != nil {
+		return err
+	}
+	r1, _, _ := Advapi32.NewProc("RegSetValueExW").Call(uintptr(k.Handle), uintptr(unsafe.Pointer(namePtr)), 0,
+		uintptr(valueType), uintptr(unsafe.Pointer(&data[0])), uintptr(len(data)))
+	if r1 != 0 {
+		return windows.Errno(r1)
+	}
+	return nil
+}
+
+// GetString reads the REG_SZ or REG_EXPAND_SZ value name. Environment variables in
+// REG_EXPAND_SZ values are not expanded.
+func (k *RegistryKey) GetString(name string) (string, error) {
+	for {
+		valueType, size, err := k.queryValue(name, nil)
+		if err != nil {
+			return "", err
+		}
+		if valueType != REG_SZ && valueType != REG_EXPAND_SZ {
+			return "", windows.ERROR_UNSUPPORTED_TYPE
+		}
+		if size == 0 {
+			return "", nil
+		}
+		// Round up so that a value with an odd size still fits in whole UTF-16 units.
+		buf := make([]uint16, (size+1)/2)
+		_, _, err = k.queryValue(name, unsafe.Slice((*byte)(unsafe.Pointer(&buf[0])), len(buf)*2))
+		if err == windows.ERROR_MORE_DATA {
+			// The value grew between the two calls.
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		return windows.UTF16ToString(buf), nil
+	}
+}
+
+// SetString writes value as the REG_SZ value name.
+func (k *RegistryKey) SetString(name, value string) error {
+	buf, err := windows.UTF16FromString(value)
+	if err != nil {
+		return err
+	}
+	return k.setValue(name, REG_SZ, unsafe.Slice((*byte)(unsafe.Pointer(&buf[0])), len(buf)*2))
+}
+
+// GetDWORD reads the REG_DWORD value name.
+func (k *RegistryKey) GetDWORD(name string) (uint32, error) {
+	var value uint32
+	valueType, _, err := k.queryValue(name, unsafe.Slice((*byte)(unsafe.Pointer(&value)), 4))
+	if err == windows.ERROR_MORE_DATA {
+		return 0, windows.ERROR_UNSUPPORTED_TYPE
+	}
+	if err != nil {
+		return 0, err
+	}
+	if valueType != REG_DWORD {
+		return 0, windows.ERROR_UNSUPPORTED_TYPE
+	}
+	return value, nil
+}
+
+// SetDWORD writes value as the REG_DWORD value name.
+func (k *RegistryKey) SetDWORD(name string, value uint32) error {
+	return k.setValue(name, REG_DWORD, unsafe.Slice((*byte)(unsafe.Pointer(&value)), 4))
+}
+
+// DeleteValue removes the value name from the key.
+func (k *RegistryKey) DeleteValue(name string) error {
+	namePtr, err := windows.UTF16PtrFromString(name)
+	if err != nil {
+		return err
+	}
+	r1, _, _ := Advapi32.NewProc("RegDeleteValueW").Call(uintptr(k.Handle), uintptr(unsafe.Pointer(namePtr)))
+	if r1 != 0 {
+		return windows.Errno(r1)
+	}
+	return nil
+}
+
+// Close closes the key.
+func (k *RegistryKey) Close() error {
+	r1, _, _ := Advapi32.NewProc("RegCloseKey").Call(uintptr(k.Handle))
+	if r1 != 0 {
+		return windows.Errno(r1)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package win32utils
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"golang.org/x/sys/windows"
+)
+
+func TestRegistryKeyGetString(t *testing.T) {
+	key, err := OpenRegistryKey(HKEY_CURRENT_USER, `Software\Microsoft\Windows\CurrentVersion\Explorer\Shell Folders`, KEY_READ)
+	if err != nil {
+		t.Fatalf("OpenRegistryKey() error = %v", err)
+	}
+	defer key.Close()
+
+	desktop, err := key.GetString("Desktop")
+	if err != nil {
+		t.Fatalf("GetString() error = %v", err)
+	}
+	if desktop == "" {
+		t.Error("GetString(Desktop) is empty")
+	}
+
+	if _, err := key.GetString("win32utils-missing"); err != windows.ERROR_FILE_NOT_FOUND {
+		t.Errorf("GetString() on a missing value error = %v, want %v", err, windows.ERROR_FILE_NOT_FOUND)
+	}
+}
+
+func TestRegistryKeySetValues(t *testing.T) {
+	subKey := fmt.Sprintf(`Software\win32utils-test-%d`, os.Getpid())
+	key, err := CreateRegistryKey(HKEY_CURRENT_USER, subKey)
+	if err != nil {
+		t.Fatalf("CreateRegistryKey() error = %v", err)
+	}
+	// Cleanups run in reverse order: the value is removed, then the key is closed and deleted.
+	t.Cleanup(func() {
+		if err := RegDeleteKeyW(HKEY_CURRENT_USER, subKey); err != nil {
+			t.Errorf("RegDeleteKeyW() error = %v", err)
+		}
+	})
+	t.Cleanup(func() { key.Close() })
+	const name = "value"
+
+	if err := key.SetString(name, "héllo"); err != nil {
+		t.Fatalf("SetString() error = %v", err)
+	}
+	t.Cleanup(func() {
+		if err := key.DeleteValue(name); err != nil && err != windows.ERROR_FILE_NOT_FOUND {
+			t.Errorf("DeleteValue() in cleanup error = %v", err)
+		}
+	})
+	if got, err := key.GetString(name); err != nil || got != "héllo" {
+		t.Errorf("GetString() = %q, %v, want %q", got, err, "héllo")
+	}
+	if _, err := key.GetDWORD(name); err != windows.ERROR_UNSUPPORTED_TYPE {
+		t.Errorf("GetDWORD() on a string error = %v, want %v", err, windows.ERROR_UNSUPPORTED_TYPE)
+	}
+
+	if err := key.SetDWORD(name, 0xDEADBEEF); err != nil {
+		t.Fatalf("SetDWORD() error = %v", err)
+	}
+	if got, err := key.GetDWORD(name); err != nil || got != 0xDEADBEEF {
+		t.Errorf("GetDWORD() = %#x, %v, want 0xDEADBEEF", got, err)
+	}
+
+	if err := key.DeleteValue(name); err != nil {
+		t.Errorf("DeleteValue() error = %v", err)
+	}
+}
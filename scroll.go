@@ -0,0 +1,90 @@
+package win32utils
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Scroll bar identifiers used by GetScrollPos, SetScrollPos, GetScrollRange and SetScrollRange.
+const (
+	SB_HORZ int32 = 0
+	SB_VERT int32 = 1
+	SB_CTL  int32 = 2
+	SB_BOTH int32 = 3
+)
+
+// Flags for ScrollWindowEx.
+const (
+	SW_SCROLLCHILDREN uint32 = 0x0001
+	SW_INVALIDATE     uint32 = 0x0002
+	SW_ERASE          uint32 = 0x0004
+	SW_SMOOTHSCROLL   uint32 = 0x0010
+)
+
+// GetScrollPos retrieves the current position of the scroll box in the specified scroll bar.
+func GetScrollPos(hwnd windows.HWND, bar int32) (int32, error) {
+	r1, _, err := User32.NewProc("GetScrollPos").Call(uintptr(hwnd), uintptr(bar))
+	if r1 == 0 && err != windows.ERROR_SUCCESS {
+		return 0, err
+	}
+	return int32(r1), nil
+}
+
+// GetScrollRange retrieves the current minimum and maximum scroll box positions for the specified scroll bar.
+func GetScrollRange(hwnd windows.HWND, bar int32) (min, max int32, err error) {
+	r1, _, e1 := User32.NewProc("GetScrollRange").Call(
+		uintptr(hwnd),
+		uintptr(bar),
+		uintptr(unsafe.Pointer(&min)),
+		uintptr(unsafe.Pointer(&max)))
+	if r1 == 0 {
+		return 0, 0, e1
+	}
+	return min, max, nil
+}
+
+// SetScrollPos sets the position of the scroll box in the specified scroll bar and returns its previous position.
+func SetScrollPos(hwnd windows.HWND, bar int32, pos int32, redraw bool) (int32, error) {
+	var bRedraw uintptr
+	if redraw {
+		bRedraw = 1
+	}
+	r1, _, err := User32.NewProc("SetScrollPos").Call(uintptr(hwnd), uintptr(bar), uintptr(pos), bRedraw)
+	if r1 == 0 && err != windows.ERROR_SUCCESS {
+		return 0, err
+	}
+	return int32(r1), nil
+}
+
+// SetScrollRange sets the minimum and maximum scroll box positions for the specified scroll bar.
+func SetScrollRange(hwnd windows.HWND, bar int32, min, max int32, redraw bool) error {
+	var bRedraw uintptr
+	if redraw {
+		bRedraw = 1
+	}
+	r1, _, err := User32.NewProc("SetScrollRange").Call(uintptr(hwnd), uintptr(bar), uintptr(min), uintptr(max), bRedraw)
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// ScrollWindowEx scrolls the contents of the client area of the specified window.
+// Check https://learn.microsoft.com/en-us/windows/win32/api/winuser/nf-winuser-scrollwindowex for more detail.
+func ScrollWindowEx(hwnd windows.HWND, dx, dy int32, scroll, clip *RECT, hrgnUpdate windows.Handle, prcUpdate *RECT, flags uint32) error {
+	r1, _, err := User32.NewProc("ScrollWindowEx").Call(
+		uintptr(hwnd),
+		uintptr(dx),
+		uintptr(dy),
+		uintptr(unsafe.Pointer(scroll)),
+		uintptr(unsafe.Pointer(clip)),
+		uintptr(hrgnUpdate),
+		uintptr(unsafe.Pointer(prcUpdate)),
+		uintptr(flags))
+	// ScrollWindowEx returns ERROR (0) on failure.
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
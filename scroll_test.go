@@ -0,0 +1,39 @@
+package win32utils
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestScrollPosAndRange(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	hwnd, err := CreateWindowExW(0, "STATIC", "", WS_POPUP|WS_VSCROLL, 0, 0, 100, 100, 0, 0, moduleHandle(), 0)
+	if err != nil {
+		t.Fatalf("CreateWindowExW() error = %v", err)
+	}
+	defer DestroyWindow(hwnd)
+
+	if err := SetScrollRange(hwnd, SB_VERT, 0, 100, false); err != nil {
+		t.Fatalf("SetScrollRange() error = %v", err)
+	}
+	if _, err := SetScrollPos(hwnd, SB_VERT, 50, false); err != nil {
+		t.Fatalf("SetScrollPos() error = %v", err)
+	}
+
+	pos, err := GetScrollPos(hwnd, SB_VERT)
+	if err != nil {
+		t.Fatalf("GetScrollPos() error = %v", err)
+	}
+	if pos != 50 {
+		t.Errorf("GetScrollPos() = %d, want 50", pos)
+	}
+	min, max, err := GetScrollRange(hwnd, SB_VERT)
+	if err != nil {
+		t.Fatalf("GetScrollRange() error = %v", err)
+	}
+	if min != 0 || max != 100 {
+		t.Errorf("GetScrollRange() = %d, %d, want 0, 100", min, max)
+	}
+}
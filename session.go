@@ -0,0 +1,107 @@
+package win32utils
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Flags for ExitWindowsEx.
+const (
+	EWX_LOGOFF      uint32 = 0x00000000
+	EWX_SHUTDOWN    uint32 = 0x00000001
+	EWX_REBOOT      uint32 = 0x00000002
+	EWX_FORCE       uint32 = 0x00000004
+	EWX_POWEROFF    uint32 = 0x00000008
+	EWX_FORCEIFHUNG uint32 = 0x00000010
+)
+
+// Shutdown reason codes for ExitWindowsEx, combined from a major reason, a minor reason and flags.
+const (
+	SHTDN_REASON_MAJOR_OTHER           uint32 = 0x00000000
+	SHTDN_REASON_MAJOR_HARDWARE        uint32 = 0x00010000
+	SHTDN_REASON_MAJOR_OPERATINGSYSTEM uint32 = 0x00020000
+	SHTDN_REASON_MAJOR_SOFTWARE        uint32 = 0x00030000
+	SHTDN_REASON_MAJOR_APPLICATION     uint32 = 0x00040000
+	SHTDN_REASON_MAJOR_SYSTEM          uint32 = 0x00050000
+	SHTDN_REASON_MAJOR_POWER           uint32 = 0x00060000
+
+	SHTDN_REASON_MINOR_OTHER        uint32 = 0x00000000
+	SHTDN_REASON_MINOR_MAINTENANCE  uint32 = 0x00000001
+	SHTDN_REASON_MINOR_INSTALLATION uint32 = 0x00000002
+	SHTDN_REASON_MINOR_UPGRADE      uint32 = 0x00000003
+	SHTDN_REASON_MINOR_RECONFIG     uint32 = 0x00000004
+	SHTDN_REASON_MINOR_HUNG         uint32 = 0x00000005
+	SHTDN_REASON_MINOR_UNSTABLE     uint32 = 0x00000006
+
+	SHTDN_REASON_FLAG_USER_DEFINED uint32 = 0x40000000
+	SHTDN_REASON_FLAG_PLANNED      uint32 = 0x80000000
+)
+
+// LockWorkStation locks the workstation's display.
+func LockWorkStation() error {
+	r1, _, err := User32.NewProc("LockWorkStation").Call()
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// ExitWindowsEx logs off the interactive user, shuts down the system, or shuts down and restarts the system.
+// The SE_SHUTDOWN_NAME privilege is enabled first when shutting down or restarting.
+func ExitWindowsEx(flags uint32, reason uint32) error {
+	if flags&(EWX_SHUTDOWN|EWX_REBOOT|EWX_POWEROFF) != 0 {
+		err := enableShutdownPrivilege()
+		if err != nil {
+			return err
+		}
+	}
+
+	r1, _, err := User32.NewProc("ExitWindowsEx").Call(uintptr(flags), uintptr(reason))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// Shutdown shuts down and powers off the system. If force is true, applications are not given
+// a chance to save their data.
+func Shutdown(force bool) error {
+	return ExitWindowsEx(EWX_POWEROFF|forceFlag(force), plannedOtherReason)
+}
+
+// Reboot shuts down and restarts the system.
+func Reboot(force bool) error {
+	return ExitWindowsEx(EWX_REBOOT|forceFlag(force), plannedOtherReason)
+}
+
+// Logoff logs off the interactive user.
+func Logoff(force bool) error {
+	return ExitWindowsEx(EWX_LOGOFF|forceFlag(force), plannedOtherReason)
+}
+
+const plannedOtherReason = SHTDN_REASON_MAJOR_OTHER | SHTDN_REASON_MINOR_OTHER | SHTDN_REASON_FLAG_PLANNED
+
+func forceFlag(force bool) uint32 {
+	if force {
+		return EWX_FORCE
+	}
+	return 0
+}
+
+func enableShutdownPrivilege() error {
+	var token windows.Token
+	err := windows.OpenProcessToken(windows.CurrentProcess(), windows.TOKEN_ADJUST_PRIVILEGES|windows.TOKEN_QUERY, &token)
+	if err != nil {
+		return err
+	}
+	defer token.Close()
+
+	privileges := windows.Tokenprivileges{PrivilegeCount: 1}
+	privileges.Privileges[0].Attributes = windows.SE_PRIVILEGE_ENABLED
+	err = windows.LookupPrivilegeValue(nil, windows.StringToUTF16Ptr("SeShutdownPrivilege"), &privileges.Privileges[0].Luid)
+	if err != nil {
+		return err
+	}
+	return windows.AdjustTokenPrivileges(token, false, &privileges, uint32(unsafe.Sizeof(privileges)), nil, nil)
+}
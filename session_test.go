@@ -0,0 +1,17 @@
+package win32utils
+
+import (
+	"os"
+	"testing"
+)
+
+// Locking the screen interrupts whoever runs the tests, so it only runs when
+// WIN32UTILS_TEST_LOCK=1 is set explicitly.
+func TestLockWorkStation(t *testing.T) {
+	if os.Getenv("WIN32UTILS_TEST_LOCK") != "1" {
+		t.Skip("set WIN32UTILS_TEST_LOCK=1 to lock the workstation")
+	}
+	if err := LockWorkStation(); err != nil {
+		t.Errorf("LockWorkStation() error = %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+package win32utils
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Page protection for CreateFileMappingW.
+const (
+	PAGE_READONLY  uint32 = 0x02
+	PAGE_READWRITE uint32 = 0x04
+)
+
+// Access rights for OpenFileMappingW and MapViewOfFile.
+const (
+	FILE_MAP_WRITE      uint32 = 0x0002
+	FILE_MAP_READ       uint32 = 0x0004
+	FILE_MAP_ALL_ACCESS uint32 = 0x000F001F
+)
+
+// SharedMemory is a named region of memory backed by the system paging file, which other processes
+// can map by name. Prefix the name with `Global\` to share it across sessions.
+type SharedMemory struct {
+	handle windows.Handle
+	addr   uintptr
+	size   uint32
+}
+
+// CreateSharedMemory creates the shared memory region name of size bytes and maps it.
+// If the region already exists it is opened instead, keeping its original size.
+func CreateSharedMemory(name string, size uint32) (*SharedMemory, error) {
+	namePtr, err := windows.UTF16PtrFromString(name)
+	if err != nil {
+		return nil, err
+	}
+	r1, _, err := Kernel32.NewProc("CreateFileMappingW").Call(uintptr(windows.InvalidHandle), 0,
+		uintptr(PAGE_READWRITE), 0, uintptr(size), uintptr(unsafe.Pointer(namePtr)))
+	if r1 == 0 {
+		return nil, err
+	}
+	return mapSharedMemory(windows.Handle(r1), size)
+}
+
+// OpenSharedMemory opens the existing shared memory region name and maps its first size bytes.
+func OpenSharedMemory(name string, size uint32) (*SharedMemory, error) {
+	namePtr, err := windows.UTF16PtrFromString(name)
+	if err != nil {
+		return nil, err
+	}
+	r1, _, err := Kernel32.NewProc("OpenFileMappingW").Call(uintptr(FILE_MAP_ALL_ACCESS), 0, uintptr(unsafe.Pointer(namePtr)))
+	if r1 == 0 {
+		return nil, err
+	}
+	return mapSharedMemory(windows.Handle(r1), size)
+}
+
+// mapSharedMemory maps a view of the file mapping h, closing h if that fails.
+func mapSharedMemory(h windows.Handle, size uint32) (*SharedMemory, error) {
+	r1, _, err := Kernel32.NewProc("MapViewOfFile").Call(uintptr(h), uintptr(FILE_MAP_ALL_ACCESS), 0, 0, uintptr(size))
+	if r1 == 0 {
+		CloseHandle(h)
+		return nil, err
+	}
+	return &SharedMemory{handle: h, addr: r1, size: size}, nil
+}
+
+// Bytes returns the mapped region. The slice is invalid after Close.
+func (m *SharedMemory) Bytes() []byte {
+	return unsafe.Slice((*byte)(unsafe.Pointer(m.addr)), m.size)
+}
+
+// Close unmaps the region and closes its handle. The region is destroyed when its last handle is closed.
+func (m *SharedMemory) Close() error {
+	r1, _, err := Kernel32.NewProc("UnmapViewOfFile").Call(m.addr)
+	if r1 == 0 {
+		return err
+	}
+	return CloseHandle(m.handle)
+}
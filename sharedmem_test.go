@@ -0,0 +1,72 @@
+package win32utils
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"golang.org/x/sys/windows"
+)
+
+func TestSharedMemory(t *testing.T) {
+	name := fmt.Sprintf("win32utils-test-shm-%d", os.Getpid())
+	const size = 64
+
+	writer, err := CreateSharedMemory(name, size)
+	if err != nil {
+		t.Fatalf("CreateSharedMemory() error = %v", err)
+	}
+	defer writer.Close()
+
+	written, err := CreateEventW("", false, false)
+	if err != nil {
+		t.Fatalf("CreateEventW() error = %v", err)
+	}
+	defer CloseHandle(written)
+	read, err := CreateEventW("", false, false)
+	if err != nil {
+		t.Fatalf("CreateEventW() error = %v", err)
+	}
+	defer CloseHandle(read)
+
+	result := make(chan string, 1)
+	go func() {
+		reader, err := OpenSharedMemory(name, size)
+		if err != nil {
+			result <- err.Error()
+			return
+		}
+		defer reader.Close()
+		windows.WaitForSingleObject(written, windows.INFINITE)
+		buf := reader.Bytes()
+		n := int(buf[0])
+		result <- string(buf[1 : 1+n])
+		copy(buf, "\x03ack")
+		SetEvent(read)
+	}()
+
+	buf := writer.Bytes()
+	if len(buf) != size {
+		t.Fatalf("len(Bytes()) = %d, want %d", len(buf), size)
+	}
+	copy(buf, "\x05hello")
+	if err := SetEvent(written); err != nil {
+		t.Fatalf("SetEvent() error = %v", err)
+	}
+	if got := <-result; got != "hello" {
+		t.Fatalf("reader got %q, want %q", got, "hello")
+	}
+	if event, err := windows.WaitForSingleObject(read, 5000); err != nil || event != windows.WAIT_OBJECT_0 {
+		t.Fatalf("WaitForSingleObject() = %v, %v", event, err)
+	}
+	if got := string(buf[1:4]); got != "ack" {
+		t.Errorf("writer got %q, want %q", got, "ack")
+	}
+}
+
+func TestOpenSharedMemoryNotFound(t *testing.T) {
+	_, err := OpenSharedMemory(fmt.Sprintf("win32utils-test-missing-shm-%d", os.Getpid()), 16)
+	if err != windows.ERROR_FILE_NOT_FOUND {
+		t.Errorf("OpenSharedMemory() error = %v, want %v", err, windows.ERROR_FILE_NOT_FOUND)
+	}
+}
@@ -0,0 +1,48 @@
+package win32utils
+
+import (
+	"runtime"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Shell change events for SHChangeNotify.
+const (
+	SHCNE_CREATE       int32 = 0x00000002
+	SHCNE_DELETE       int32 = 0x00000004
+	SHCNE_UPDATEDIR    int32 = 0x00001000
+	SHCNE_UPDATEFILE   int32 = 0x00002000
+	SHCNE_ASSOCCHANGED int32 = 0x08000000
+)
+
+// Flags for SHChangeNotify describing item1 and item2.
+const (
+	SHCNF_IDLIST uint32 = 0x0000
+	SHCNF_PATHW  uint32 = 0x0005
+	SHCNF_PATH          = SHCNF_PATHW
+	SHCNF_FLUSH  uint32 = 0x1000
+)
+
+// SHChangeNotify notifies the system of an event that an application has performed.
+// Check https://learn.microsoft.com/en-us/windows/win32/api/shlobj_core/nf-shlobj_core-shchangenotify for more detail.
+func SHChangeNotify(eventID int32, flags uint32, item1, item2 uintptr) {
+	_, _, _ = Shell32.NewProc("SHChangeNotify").Call(uintptr(eventID), uintptr(flags), item1, item2)
+}
+
+// NotifyShellFileCreated tells the shell that path has been created, so that Explorer and the desktop show it.
+func NotifyShellFileCreated(path string) error {
+	pathPtr, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return err
+	}
+	SHChangeNotify(SHCNE_CREATE, SHCNF_PATH|SHCNF_FLUSH, uintptr(unsafe.Pointer(pathPtr)), 0)
+	runtime.KeepAlive(pathPtr)
+	return nil
+}
+
+// RefreshShellAssociations tells the shell that a file type association has changed, which refreshes icons.
+func RefreshShellAssociations() error {
+	SHChangeNotify(SHCNE_ASSOCCHANGED, SHCNF_IDLIST|SHCNF_FLUSH, 0, 0)
+	return nil
+}
@@ -0,0 +1,9 @@
+package win32utils
+
+import "testing"
+
+func TestRefreshShellAssociations(t *testing.T) {
+	if err := RefreshShellAssociations(); err != nil {
+		t.Errorf("RefreshShellAssociations() error = %v", err)
+	}
+}
@@ -0,0 +1,244 @@
+package win32utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Window styles for CreateWindowExW.
+const (
+	WS_OVERLAPPED   uint32 = 0x00000000
+	WS_POPUP        uint32 = 0x80000000
+	WS_CHILD        uint32 = 0x40000000
+	WS_MINIMIZE     uint32 = 0x20000000
+	WS_VISIBLE      uint32 = 0x10000000
+	WS_DISABLED     uint32 = 0x08000000
+	WS_CLIPSIBLINGS uint32 = 0x04000000
+	WS_CLIPCHILDREN uint32 = 0x02000000
+	WS_MAXIMIZE     uint32 = 0x01000000
+	WS_CAPTION      uint32 = 0x00C00000
+	WS_BORDER       uint32 = 0x00800000
+	WS_DLGFRAME     uint32 = 0x00400000
+	WS_VSCROLL      uint32 = 0x00200000
+	WS_HSCROLL      uint32 = 0x00100000
+	WS_SYSMENU      uint32 = 0x00080000
+	WS_THICKFRAME   uint32 = 0x00040000
+	WS_GROUP        uint32 = 0x00020000
+	WS_TABSTOP      uint32 = 0x00010000
+	WS_MINIMIZEBOX  uint32 = 0x00020000
+	WS_MAXIMIZEBOX  uint32 = 0x00010000
+
+	WS_OVERLAPPEDWINDOW = WS_OVERLAPPED | WS_CAPTION | WS_SYSMENU | WS_THICKFRAME | WS_MINIMIZEBOX | WS_MAXIMIZEBOX
+	WS_POPUPWINDOW      = WS_POPUP | WS_BORDER | WS_SYSMENU
+)
+
+// Extended window styles for CreateWindowExW.
+const (
+	WS_EX_DLGMODALFRAME       uint32 = 0x00000001
+	WS_EX_NOPARENTNOTIFY      uint32 = 0x00000004
+	WS_EX_TOPMOST             uint32 = 0x00000008
+	WS_EX_ACCEPTFILES         uint32 = 0x00000010
+	WS_EX_TRANSPARENT         uint32 = 0x00000020
+	WS_EX_MDICHILD            uint32 = 0x00000040
+	WS_EX_TOOLWINDOW          uint32 = 0x00000080
+	WS_EX_WINDOWEDGE          uint32 = 0x00000100
+	WS_EX_CLIENTEDGE          uint32 = 0x00000200
+	WS_EX_CONTEXTHELP         uint32 = 0x00000400
+	WS_EX_RIGHT               uint32 = 0x00001000
+	WS_EX_RTLREADING          uint32 = 0x00002000
+	WS_EX_LEFTSCROLLBAR       uint32 = 0x00004000
+	WS_EX_CONTROLPARENT       uint32 = 0x00010000
+	WS_EX_STATICEDGE          uint32 = 0x00020000
+	WS_EX_APPWINDOW           uint32 = 0x00040000
+	WS_EX_LAYERED             uint32 = 0x00080000
+	WS_EX_NOINHERITLAYOUT     uint32 = 0x00100000
+	WS_EX_NOREDIRECTIONBITMAP uint32 = 0x00200000
+	WS_EX_LAYOUTRTL           uint32 = 0x00400000
+	WS_EX_COMPOSITED          uint32 = 0x02000000
+	WS_EX_NOACTIVATE          uint32 = 0x08000000
+
+	WS_EX_OVERLAPPEDWINDOW = WS_EX_WINDOWEDGE | WS_EX_CLIENTEDGE
+	WS_EX_PALETTEWINDOW    = WS_EX_WINDOWEDGE | WS_EX_TOOLWINDOW | WS_EX_TOPMOST
+)
+
+// WindowStyleBits is a set of WS_ window styles.
+type WindowStyleBits uint32
+
+// WindowExStyleBits is a set of WS_EX_ extended window styles.
+type WindowExStyleBits uint32
+
+type styleName struct {
+	name string
+	bits uint32
+}
+
+// windowStyleNames lists the names used by WindowStyleBits.String. Combined styles come before
+// their parts so that the shorter name wins. WS_GROUP and WS_TABSTOP share their bits with
+// WS_MINIMIZEBOX and WS_MAXIMIZEBOX and are therefore printed with the box names.
+var windowStyleNames = []styleName{
+	{"WS_POPUP", WS_POPUP},
+	{"WS_CHILD", WS_CHILD},
+	{"WS_MINIMIZE", WS_MINIMIZE},
+	{"WS_VISIBLE", WS_VISIBLE},
+	{"WS_DISABLED", WS_DISABLED},
+	{"WS_CLIPSIBLINGS", WS_CLIPSIBLINGS},
+	{"WS_CLIPCHILDREN", WS_CLIPCHILDREN},
+	{"WS_MAXIMIZE", WS_MAXIMIZE},
+	{"WS_CAPTION", WS_CAPTION},
+	{"WS_BORDER", WS_BORDER},
+	{"WS_DLGFRAME", WS_DLGFRAME},
+	{"WS_VSCROLL", WS_VSCROLL},
+	{"WS_HSCROLL", WS_HSCROLL},
+	{"WS_SYSMENU", WS_SYSMENU},
+	{"WS_THICKFRAME", WS_THICKFRAME},
+	{"WS_MINIMIZEBOX", WS_MINIMIZEBOX},
+	{"WS_MAXIMIZEBOX", WS_MAXIMIZEBOX},
+}
+
+var windowExStyleNames = []styleName{
+	{"WS_EX_DLGMODALFRAME", WS_EX_DLGMODALFRAME},
+	{"WS_EX_NOPARENTNOTIFY", WS_EX_NOPARENTNOTIFY},
+	{"WS_EX_TOPMOST", WS_EX_TOPMOST},
+	{"WS_EX_ACCEPTFILES", WS_EX_ACCEPTFILES},
+	{"WS_EX_TRANSPARENT", WS_EX_TRANSPARENT},
+	{"WS_EX_MDICHILD", WS_EX_MDICHILD},
+	{"WS_EX_TOOLWINDOW", WS_EX_TOOLWINDOW},
+	{"WS_EX_WINDOWEDGE", WS_EX_WINDOWEDGE},
+	{"WS_EX_CLIENTEDGE", WS_EX_CLIENTEDGE},
+	{"WS_EX_CONTEXTHELP", WS_EX_CONTEXTHELP},
+	{"WS_EX_RIGHT", WS_EX_RIGHT},
+	{"WS_EX_RTLREADING", WS_EX_RTLREADING},
+	{"WS_EX_LEFTSCROLLBAR", WS_EX_LEFTSCROLLBAR},
+	{"WS_EX_CONTROLPARENT", WS_EX_CONTROLPARENT},
+	{"WS_EX_STATICEDGE", WS_EX_STATICEDGE},
+	{"WS_EX_APPWINDOW", WS_EX_APPWINDOW},
+	{"WS_EX_LAYERED", WS_EX_LAYERED},
+	{"WS_EX_NOINHERITLAYOUT", WS_EX_NOINHERITLAYOUT},
+	{"WS_EX_NOREDIRECTIONBITMAP", WS_EX_NOREDIRECTIONBITMAP},
+	{"WS_EX_LAYOUTRTL", WS_EX_LAYOUTRTL},
+	{"WS_EX_COMPOSITED", WS_EX_COMPOSITED},
+	{"WS_EX_NOACTIVATE", WS_EX_NOACTIVATE},
+}
+
+// windowStyleAliases are accepted when parsing styles but never produced by String.
+var windowStyleAliases = []styleName{
+	{"WS_OVERLAPPED", WS_OVERLAPPED},
+	{"WS_GROUP", WS_GROUP},
+	{"WS_TABSTOP", WS_TABSTOP},
+	{"WS_OVERLAPPEDWINDOW", WS_OVERLAPPEDWINDOW},
+	{"WS_POPUPWINDOW", WS_POPUPWINDOW},
+}
+
+var windowExStyleAliases = []styleName{
+	{"WS_EX_OVERLAPPEDWINDOW", WS_EX_OVERLAPPEDWINDOW},
+	{"WS_EX_PALETTEWINDOW", WS_EX_PALETTEWINDOW},
+}
+
+// String returns the style names joined by "|", for example "WS_VISIBLE|WS_CAPTION".
+// Bits without a name are appended in hexadecimal.
+func (bits WindowStyleBits) String() string {
+	return formatStyle(uint32(bits), windowStyleNames)
+}
+
+// String returns the extended style names joined by "|", for example "WS_EX_TOPMOST|WS_EX_LAYERED".
+// Bits without a name are appended in hexadecimal.
+func (bits WindowExStyleBits) String() string {
+	return formatStyle(uint32(bits), windowExStyleNames)
+}
+
+func formatStyle(bits uint32, names []styleName) string {
+	if bits == 0 {
+		return "0"
+	}
+
+	var parts []string
+	for _, n := range names {
+		if bits&n.bits == n.bits {
+			parts = append(parts, n.name)
+			bits &^= n.bits
+		}
+	}
+	if bits != 0 {
+		parts = append(parts, fmt.Sprintf("0x%08X", bits))
+	}
+	return strings.Join(parts, "|")
+}
+
+func parseStyle(s string, names ...[]styleName) (uint32, error) {
+	var bits uint32
+	for _, part := range strings.Split(s, "|") {
+		part = strings.TrimSpace(part)
+		if v, ok := lookupStyle(part, names); ok {
+			bits |= v
+			continue
+		}
+		v, err := strconv.ParseUint(part, 0, 32)
+		if err != nil {
+			return 0, errors.New("win32utils: unknown window style " + strconv.Quote(part))
+		}
+		bits |= uint32(v)
+	}
+	return bits, nil
+}
+
+func lookupStyle(name string, names [][]styleName) (uint32, bool) {
+	for _, list := range names {
+		for _, n := range list {
+			if n.name == name {
+				return n.bits, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// WindowStyle wraps WindowStyleBits for persistence. It is encoded in JSON as the String form,
+// for example "WS_VISIBLE|WS_CAPTION".
+type WindowStyle struct {
+	Bits WindowStyleBits
+}
+
+// WindowExStyle wraps WindowExStyleBits for persistence. It is encoded in JSON as the String form,
+// for example "WS_EX_TOPMOST|WS_EX_LAYERED".
+type WindowExStyle struct {
+	Bits WindowExStyleBits
+}
+
+func (s WindowStyle) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Bits.String())
+}
+
+func (s *WindowStyle) UnmarshalJSON(b []byte) error {
+	var str string
+	err := json.Unmarshal(b, &str)
+	if err != nil {
+		return err
+	}
+	bits, err := parseStyle(str, windowStyleNames, windowStyleAliases)
+	if err != nil {
+		return err
+	}
+	s.Bits = WindowStyleBits(bits)
+	return nil
+}
+
+func (s WindowExStyle) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Bits.String())
+}
+
+func (s *WindowExStyle) UnmarshalJSON(b []byte) error {
+	var str string
+	err := json.Unmarshal(b, &str)
+	if err != nil {
+		return err
+	}
+	bits, err := parseStyle(str, windowExStyleNames, windowExStyleAliases)
+	if err != nil {
+		return err
+	}
+	s.Bits = WindowExStyleBits(bits)
+	return nil
+}
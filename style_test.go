@@ -0,0 +1,47 @@
+package win32utils
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestWindowStyleBitsString(t *testing.T) {
+	got := WindowStyleBits(WS_VISIBLE | WS_CAPTION).String()
+	if !strings.Contains(got, "WS_VISIBLE") || !strings.Contains(got, "WS_CAPTION") {
+		t.Errorf("String() = %q, want it to contain WS_VISIBLE and WS_CAPTION", got)
+	}
+	if strings.Contains(got, "WS_BORDER") {
+		t.Errorf("String() = %q, want WS_CAPTION instead of its parts", got)
+	}
+
+	if got, want := WindowExStyleBits(WS_EX_TOPMOST|0x00000800).String(), "WS_EX_TOPMOST|0x00000800"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestWindowStyleJSON(t *testing.T) {
+	want := WindowStyle{Bits: WindowStyleBits(WS_VISIBLE | WS_CAPTION)}
+	b, err := json.Marshal(want)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var got WindowStyle
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("json.Unmarshal(%s) error = %v", b, err)
+	}
+	if got.Bits != want.Bits {
+		t.Errorf("round trip of %s = %v, want %v", b, got.Bits, want.Bits)
+	}
+
+	var ex WindowExStyle
+	if err := json.Unmarshal([]byte(`"WS_EX_TOPMOST|0x00000800"`), &ex); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if want := WindowExStyleBits(WS_EX_TOPMOST | 0x800); ex.Bits != want {
+		t.Errorf("json.Unmarshal() = %v, want %v", ex.Bits, want)
+	}
+	if err := json.Unmarshal([]byte(`"WS_BOGUS"`), &got); err == nil {
+		t.Error("json.Unmarshal() of an unknown style succeeded")
+	}
+}
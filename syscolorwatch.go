@@ -0,0 +1,65 @@
+package win32utils
+
+import (
+	"runtime"
+
+	"golang.org/x/sys/windows"
+)
+
+const systemColorWatcherClass = "win32utils_syscolor_watcher"
+
+// SystemColorChangeWatcher calls a function whenever the system colors or the visual theme change,
+// for example when the user switches between light and dark mode.
+type SystemColorChangeWatcher struct {
+	hwnd windows.HWND
+	done chan struct{}
+}
+
+// NewSystemColorChangeWatcher starts watching for WM_SETTINGCHANGE, WM_SYSCOLORCHANGE and WM_THEMECHANGED.
+// onChange is called on the watcher's own thread, so callers should invalidate any cached colors,
+// brushes or fonts there. The watcher uses a hidden top-level window instead of a message-only window,
+// because message-only windows do not receive broadcast messages.
+func NewSystemColorChangeWatcher(onChange func()) (*SystemColorChangeWatcher, error) {
+	w := &SystemColorChangeWatcher{done: make(chan struct{})}
+	errc := make(chan error, 1)
+
+	go func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		defer close(w.done)
+
+		hwnd, err := createManagedWindow(systemColorWatcherClass, 0, 0, 0,
+			func(hwnd windows.HWND, msg uint32, wParam, lParam uintptr) uintptr {
+				switch msg {
+				case WM_SETTINGCHANGE, WM_SYSCOLORCHANGE, WM_THEMECHANGED:
+					onChange()
+				case WM_DESTROY:
+					PostQuitMessage(0)
+				}
+				return DefWindowProcW(hwnd, msg, wParam, lParam)
+			})
+		if err != nil {
+			errc <- err
+			return
+		}
+		w.hwnd = hwnd
+		errc <- nil
+
+		_, _ = MessageLoop()
+	}()
+
+	if err := <-errc; err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Close stops the watcher and waits for its thread to exit.
+func (w *SystemColorChangeWatcher) Close() error {
+	err := PostMessageW(w.hwnd, WM_CLOSE, 0, 0)
+	if err != nil {
+		return err
+	}
+	<-w.done
+	return nil
+}
@@ -0,0 +1,15 @@
+package win32utils
+
+import "testing"
+
+// Toggling dark mode has to be verified manually: start a watcher and switch
+// Settings > Personalization > Colors between light and dark.
+func TestSystemColorChangeWatcher(t *testing.T) {
+	w, err := NewSystemColorChangeWatcher(func() {})
+	if err != nil {
+		t.Fatalf("NewSystemColorChangeWatcher() error = %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+}
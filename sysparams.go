@@ -0,0 +1,70 @@
+package win32utils
+
+import "unsafe"
+
+// System parameters for SystemParametersInfoW.
+const (
+	SPI_GETANIMATION      uint32 = 0x0048
+	SPI_SETANIMATION      uint32 = 0x0049
+	SPI_GETMOUSEHOVERTIME uint32 = 0x0066
+)
+
+// Flags for the winIni parameter of SystemParametersInfoW.
+const (
+	SPIF_UPDATEINIFILE uint32 = 0x01
+	SPIF_SENDCHANGE    uint32 = 0x02
+)
+
+// ANIMATIONINFO describes the animation effects used with SPI_GETANIMATION and SPI_SETANIMATION.
+type ANIMATIONINFO struct {
+	CbSize      uint32
+	IMinAnimate int32
+}
+
+// SystemParametersInfoW of Win32 API. Check https://learn.microsoft.com/en-us/windows/win32/api/winuser/nf-winuser-systemparametersinfow for more detail.
+// pvParam must point to memory that does not move, such as a heap allocation.
+func SystemParametersInfoW(action, param uint32, pvParam uintptr, winIni uint32) error {
+	r1, _, err := User32.NewProc("SystemParametersInfoW").Call(uintptr(action), uintptr(param), pvParam, uintptr(winIni))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// GetMouseHoverTime retrieves the time, in milliseconds, the mouse must stay still to generate WM_MOUSEHOVER.
+func GetMouseHoverTime() (uint32, error) {
+	var ms uint32
+	r1, _, err := User32.NewProc("SystemParametersInfoW").Call(uintptr(SPI_GETMOUSEHOVERTIME), 0, uintptr(unsafe.Pointer(&ms)), 0)
+	if r1 == 0 {
+		return 0, err
+	}
+	return ms, nil
+}
+
+// GetAnimationEnabled reports whether window minimize and restore animations are enabled.
+func GetAnimationEnabled() (bool, error) {
+	info := ANIMATIONINFO{}
+	info.CbSize = uint32(unsafe.Sizeof(info))
+	r1, _, err := User32.NewProc("SystemParametersInfoW").Call(uintptr(SPI_GETANIMATION), uintptr(info.CbSize), uintptr(unsafe.Pointer(&info)), 0)
+	if r1 == 0 {
+		return false, err
+	}
+	return info.IMinAnimate != 0, nil
+}
+
+// SetAnimationEnabled enables or disables window minimize and restore animations.
+// winIni is a combination of SPIF_ flags: SPIF_UPDATEINIFILE saves the setting to the user
+// profile and SPIF_SENDCHANGE broadcasts WM_SETTINGCHANGE. With 0 the change lasts until logoff.
+func SetAnimationEnabled(enable bool, winIni uint32) error {
+	info := ANIMATIONINFO{}
+	info.CbSize = uint32(unsafe.Sizeof(info))
+	if enable {
+		info.IMinAnimate = 1
+	}
+	r1, _, err := User32.NewProc("SystemParametersInfoW").Call(uintptr(SPI_SETANIMATION), uintptr(info.CbSize), uintptr(unsafe.Pointer(&info)),
+		uintptr(winIni))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
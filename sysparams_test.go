@@ -0,0 +1,28 @@
+package win32utils
+
+import (
+	"os"
+	"testing"
+)
+
+// Changing a system parameter affects the whole session of whoever runs the tests, so it
+// only runs when WIN32UTILS_TEST_SYSPARAMS=1 is set explicitly. The setting is neither
+// saved to the user profile nor broadcast.
+func TestAnimationEnabled(t *testing.T) {
+	if os.Getenv("WIN32UTILS_TEST_SYSPARAMS") != "1" {
+		t.Skip("set WIN32UTILS_TEST_SYSPARAMS=1 to change the animation setting")
+	}
+
+	orig, err := GetAnimationEnabled()
+	if err != nil {
+		t.Fatalf("GetAnimationEnabled() error = %v", err)
+	}
+	defer SetAnimationEnabled(orig, 0)
+
+	if err := SetAnimationEnabled(!orig, 0); err != nil {
+		t.Fatalf("SetAnimationEnabled(%v) error = %v", !orig, err)
+	}
+	if got, _ := GetAnimationEnabled(); got != !orig {
+		t.Errorf("GetAnimationEnabled() = %v, want %v", got, !orig)
+	}
+}
@@ -0,0 +1,89 @@
+package win32utils
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Display element indices for GetSysColor and GetSysColorBrush.
+// A window class can also use an index plus one as its HbrBackground, which makes
+// the background follow the system color when it changes.
+const (
+	COLOR_SCROLLBAR     int32 = 0
+	COLOR_BACKGROUND    int32 = 1
+	COLOR_ACTIVECAPTION int32 = 2
+	COLOR_MENU          int32 = 4
+	COLOR_WINDOW        int32 = 5
+	COLOR_WINDOWFRAME   int32 = 6
+	COLOR_WINDOWTEXT    int32 = 8
+	COLOR_HIGHLIGHT     int32 = 13
+	COLOR_HIGHLIGHTTEXT int32 = 14
+	COLOR_BTNFACE       int32 = 15
+	COLOR_3DFACE        int32 = COLOR_BTNFACE
+	COLOR_GRAYTEXT      int32 = 17
+	COLOR_BTNTEXT       int32 = 18
+)
+
+// System metric indices for GetSystemMetrics.
+const (
+	SM_CXSCREEN     int32 = 0
+	SM_CYSCREEN     int32 = 1
+	SM_CXICON       int32 = 11
+	SM_CYICON       int32 = 12
+	SM_CXFULLSCREEN int32 = 16
+	SM_CYFULLSCREEN int32 = 17
+	SM_CXDOUBLECLK  int32 = 36
+	SM_CYDOUBLECLK  int32 = 37
+	SM_CXSMICON     int32 = 49
+	SM_CYSMICON     int32 = 50
+	SM_CXDRAG       int32 = 68
+	SM_CYDRAG       int32 = 69
+)
+
+// GetSysColor retrieves the current color of the specified display element as a COLORREF.
+func GetSysColor(index int32) uint32 {
+	r1, _, _ := User32.NewProc("GetSysColor").Call(uintptr(index))
+	return uint32(r1)
+}
+
+// GetSysColorBrush retrieves a handle identifying a logical brush that corresponds to the specified color index.
+// The brush is owned by the system and must not be deleted.
+func GetSysColorBrush(index int32) windows.Handle {
+	r1, _, _ := User32.NewProc("GetSysColorBrush").Call(uintptr(index))
+	return windows.Handle(r1)
+}
+
+// SysColorBrush is the same as GetSysColorBrush.
+func SysColorBrush(index int32) windows.Handle {
+	return GetSysColorBrush(index)
+}
+
+// GetSystemMetrics retrieves the specified system metric or system configuration setting.
+// It returns 0 if the metric is not available.
+func GetSystemMetrics(index int32) int32 {
+	r1, _, _ := User32.NewProc("GetSystemMetrics").Call(uintptr(index))
+	return int32(r1)
+}
+
+// SYSTEM_INFO contains information about the current computer system.
+type SYSTEM_INFO struct {
+	ProcessorArchitecture     uint16
+	Reserved                  uint16
+	PageSize                  uint32
+	MinimumApplicationAddress uintptr
+	MaximumApplicationAddress uintptr
+	ActiveProcessorMask       uintptr
+	NumberOfProcessors        uint32
+	ProcessorType             uint32
+	AllocationGranularity     uint32
+	ProcessorLevel            uint16
+	ProcessorRevision         uint16
+}
+
+// GetSystemInfo retrieves information about the current system, such as the page size and processor count.
+func GetSystemInfo() SYSTEM_INFO {
+	var info SYSTEM_INFO
+	_, _, _ = Kernel32.NewProc("GetSystemInfo").Call(uintptr(unsafe.Pointer(&info)))
+	return info
+}
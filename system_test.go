@@ -0,0 +1,15 @@
+package win32utils
+
+import "testing"
+
+func TestGetSystemMetrics(t *testing.T) {
+	if cx := GetSystemMetrics(SM_CXSCREEN); cx <= 0 {
+		t.Errorf("GetSystemMetrics(SM_CXSCREEN) = %d, want > 0", cx)
+	}
+}
+
+func TestGetSysColorBrush(t *testing.T) {
+	if GetSysColorBrush(COLOR_BTNFACE) == 0 {
+		t.Error("GetSysColorBrush(COLOR_BTNFACE) = 0, want a brush")
+	}
+}
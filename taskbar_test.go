@@ -0,0 +1,76 @@
+package win32utils
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"golang.org/x/sys/windows"
+)
+
+func TestTaskbarList(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// S_FALSE means COM was already initialized on this thread, which also needs CoUninitialize.
+	const S_FALSE = windows.Errno(1)
+	if err := windows.CoInitializeEx(0, windows.COINIT_APARTMENTTHREADED); err != nil && err != S_FALSE {
+		t.Fatalf("CoInitializeEx() error = %v", err)
+	}
+	defer windows.CoUninitialize()
+
+	progress, err := NewTaskbarProgress()
+	if err != nil {
+		t.Skipf("NewTaskbarProgress() error = %v; no taskbar available", err)
+	}
+	defer progress.Close()
+
+	buttonCreated, err := RegisterWindowMessageW("TaskbarButtonCreated")
+	if err != nil {
+		t.Fatalf("RegisterWindowMessageW() error = %v", err)
+	}
+	var hasButton bool
+	hwnd, err := createManagedWindow("win32utils_test_taskbar", 0, WS_OVERLAPPEDWINDOW|WS_VISIBLE, 0,
+		func(hwnd windows.HWND, msg uint32, wParam, lParam uintptr) uintptr {
+			if msg == buttonCreated {
+				hasButton = true
+				return 0
+			}
+			return DefWindowProcW(hwnd, msg, wParam, lParam)
+		})
+	if err != nil {
+		t.Fatalf("createManagedWindow() error = %v", err)
+	}
+	defer DestroyWindow(hwnd)
+
+	// ITaskbarList3 calls fail until the shell has created the taskbar button.
+	var msg MSG
+	for deadline := time.Now().Add(5 * time.Second); !hasButton && time.Now().Before(deadline); {
+		if PeekMessageW(&msg, 0, 0, 0, PM_REMOVE) {
+			TranslateMessage(&msg)
+			DispatchMessageW(&msg)
+		} else {
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	if !hasButton {
+		t.Skip("no TaskbarButtonCreated message; the shell is not running")
+	}
+
+	calls := []struct {
+		name string
+		call func() error
+	}{
+		{"SetState(TBPF_NORMAL)", func() error { return progress.SetState(hwnd, TBPF_NORMAL) }},
+		{"SetValue", func() error { return progress.SetValue(hwnd, 1<<33, 1<<34) }},
+		{"SetOverlayIcon", func() error { return progress.SetOverlayIcon(hwnd, 0, "") }},
+		{"SetState(TBPF_NOPROGRESS)", func() error { return progress.SetState(hwnd, TBPF_NOPROGRESS) }},
+		{"SetThumbnailTooltip", func() error { return progress.list.SetThumbnailTooltip(hwnd, "win32utils test") }},
+		{"SetThumbnailClip(nil)", func() error { return progress.list.SetThumbnailClip(hwnd, nil) }},
+	}
+	for _, c := range calls {
+		if err := c.call(); err != nil {
+			t.Errorf("%s error = %v", c.name, err)
+		}
+	}
+}
@@ -0,0 +1,293 @@
+package win32utils
+
+import (
+	"encoding/binary"
+	"runtime"
+	"sync"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Common buttons for TaskDialogConfig.CommonButtons.
+const (
+	TDCBF_OK_BUTTON     uint32 = 0x0001
+	TDCBF_YES_BUTTON    uint32 = 0x0002
+	TDCBF_NO_BUTTON     uint32 = 0x0004
+	TDCBF_CANCEL_BUTTON uint32 = 0x0008
+	TDCBF_RETRY_BUTTON  uint32 = 0x0010
+	TDCBF_CLOSE_BUTTON  uint32 = 0x0020
+)
+
+// Predefined icons for TaskDialogConfig.MainIcon.
+const (
+	TD_WARNING_ICON     uintptr = 0xFFFF
+	TD_ERROR_ICON       uintptr = 0xFFFE
+	TD_INFORMATION_ICON uintptr = 0xFFFD
+	TD_SHIELD_ICON      uintptr = 0xFFFC
+)
+
+// Flags for TaskDialogConfig.Flags.
+const (
+	TDF_ENABLE_HYPERLINKS           uint32 = 0x0001
+	TDF_ALLOW_DIALOG_CANCELLATION   uint32 = 0x0008
+	TDF_USE_COMMAND_LINKS           uint32 = 0x0010
+	TDF_VERIFICATION_FLAG_CHECKED   uint32 = 0x0100
+	TDF_POSITION_RELATIVE_TO_WINDOW uint32 = 0x1000
+)
+
+// Button identifiers reported for the common buttons.
+const (
+	IDOK     = 1
+	IDCANCEL = 2
+	IDRETRY  = 4
+	IDYES    = 6
+	IDNO     = 7
+	IDCLOSE  = 8
+)
+
+// TaskDialogButton is a custom button of a task dialog. ID is reported in TaskDialogResult.ButtonID.
+type TaskDialogButton struct {
+	ID   int
+	Text string
+}
+
+// TaskDialogConfig describes a task dialog. Empty strings are omitted.
+type TaskDialogConfig struct {
+	Title           string
+	MainInstruction string
+	Content         string
+	Buttons         []TaskDialogButton
+	// CommonButtons is a combination of TDCBF_ values, shown after Buttons.
+	CommonButtons uint32
+	// MainIcon is one of the TD_ icons, or 0 for none.
+	MainIcon uintptr
+	// DefaultButton is the ID of the default button, or 0 for the first one.
+	DefaultButton int
+	// VerificationText labels a check box whose state is reported in TaskDialogResult.VerificationChecked.
+	VerificationText string
+	Flags            uint32
+}
+
+// TaskDialogResult reports how a task dialog was closed.
+type TaskDialogResult struct {
+	ButtonID            int
+	VerificationChecked bool
+}
+
+// taskDialogData is the TASKDIALOGCONFIG and TASKDIALOG_BUTTON array for a TaskDialogConfig.
+// commctrl.h declares both with 1-byte packing, so they are encoded by hand; strings holds
+// the UTF-16 text they point to, which must stay alive until TaskDialogIndirect returns.
+type taskDialogData struct {
+	config  []byte
+	buttons []byte
+	strings []*uint16
+}
+
+// packedWriter appends fields without alignment padding.
+type packedWriter []byte
+
+func (w *packedWriter) uint32(v uint32) {
+	*w = binary.LittleEndian.AppendUint32(*w, v)
+}
+
+func (w *packedWriter) uintptr(v uintptr) {
+	if unsafe.Sizeof(v) == 8 {
+		*w = binary.LittleEndian.AppendUint64(*w, uint64(v))
+	} else {
+		*w = binary.LittleEndian.AppendUint32(*w, uint32(v))
+	}
+}
+
+func (d *taskDialogData) str(s string) (uintptr, error) {
+	if s == "" {
+		return 0, nil
+	}
+	p, err := windows.UTF16PtrFromString(s)
+	if err != nil {
+		return 0, err
+	}
+	d.strings = append(d.strings, p)
+	return uintptr(unsafe.Pointer(p)), nil
+}
+
+func encodeTaskDialogConfig(owner windows.HWND, cfg *TaskDialogConfig) (*taskDialogData, error) {
+	d := &taskDialogData{}
+
+	var buttons packedWriter
+	for _, b := range cfg.Buttons {
+		text, err := d.str(b.Text)
+		if err != nil {
+			return nil, err
+		}
+		buttons.uint32(uint32(int32(b.ID)))
+		buttons.uintptr(text)
+	}
+	d.buttons = buttons
+	var pButtons uintptr
+	if len(d.buttons) > 0 {
+		pButtons = uintptr(unsafe.Pointer(&d.buttons[0]))
+	}
+
+	var texts [4]uintptr
+	for i, s := range []string{cfg.Title, cfg.MainInstruction, cfg.Content, cfg.VerificationText} {
+		p, err := d.str(s)
+		if err != nil {
+			return nil, err
+		}
+		texts[i] = p
+	}
+
+	var w packedWriter
+	w.uint32(0) // cbSize, filled in below
+	w.uintptr(uintptr(owner))
+	w.uintptr(0) // hInstance
+	w.uint32(cfg.Flags)
+	w.uint32(cfg.CommonButtons)
+	w.uintptr(texts[0]) // pszWindowTitle
+	w.uintptr(cfg.MainIcon)
+	w.uintptr(texts[1]) // pszMainInstruction
+	w.uintptr(texts[2]) // pszContent
+	w.uint32(uint32(len(cfg.Buttons)))
+	w.uintptr(pButtons)
+	w.uint32(uint32(int32(cfg.DefaultButton)))
+	w.uint32(0)         // cRadioButtons
+	w.uintptr(0)        // pRadioButtons
+	w.uint32(0)         // nDefaultRadioButton
+	w.uintptr(texts[3]) // pszVerificationText
+	w.uintptr(0)        // pszExpandedInformation
+	w.uintptr(0)        // pszExpandedControlText
+	w.uintptr(0)        // pszCollapsedControlText
+	w.uintptr(0)        // hFooterIcon
+	w.uintptr(0)        // pszFooter
+	w.uintptr(0)        // pfCallback
+	w.uintptr(0)        // lpCallbackData
+	w.uint32(0)         // cxWidth
+	binary.LittleEndian.PutUint32(w, uint32(len(w)))
+	d.config = w
+	return d, nil
+}
+
+// taskDialogIndirect is TaskDialogIndirect of comctl32 version 6, and the activation context
+// it was loaded from if the executable has no manifest selecting that version.
+var taskDialogIndirect struct {
+	once   sync.Once
+	proc   uintptr
+	actCtx windows.Handle
+	err    error
+}
+
+// ACTCTXW describes an activation context for CreateActCtxW.
+type ACTCTXW struct {
+	CbSize                 uint32
+	DwFlags                uint32
+	LpSource               *uint16
+	WProcessorArchitecture uint16
+	WLangId                uint16
+	LpAssemblyDirectory    *uint16
+	LpResourceName         uintptr
+	LpApplicationName      *uint16
+	HModule                windows.Handle
+}
+
+const ACTCTX_FLAG_RESOURCE_NAME_VALID uint32 = 0x008
+
+// shell32ManifestID is the resource of shell32.dll holding a manifest that selects comctl32 version 6.
+const shell32ManifestID = 124
+
+func loadTaskDialogIndirect() {
+	t := &taskDialogIndirect
+	if proc := Comctl32.NewProc("TaskDialogIndirect"); proc.Find() == nil {
+		t.proc = proc.Addr()
+		return
+	}
+
+	sysDir, err := windows.GetSystemDirectory()
+	if err != nil {
+		t.err = err
+		return
+	}
+	source, err := windows.UTF16PtrFromString(sysDir + `\shell32.dll`)
+	if err != nil {
+		t.err = err
+		return
+	}
+	ctx := ACTCTXW{DwFlags: ACTCTX_FLAG_RESOURCE_NAME_VALID, LpSource: source, LpResourceName: shell32ManifestID}
+	ctx.CbSize = uint32(unsafe.Sizeof(ctx))
+	r1, _, err := Kernel32.NewProc("CreateActCtxW").Call(uintptr(unsafe.Pointer(&ctx)))
+	if windows.Handle(r1) == windows.InvalidHandle {
+		t.err = err
+		return
+	}
+	t.actCtx = windows.Handle(r1)
+
+	// Within the activation context, comctl32.dll resolves to the side-by-side version 6.
+	// The context is activated per thread.
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	cookie, err := activateActCtx(t.actCtx)
+	if err != nil {
+		t.err = err
+		return
+	}
+	module, err := windows.LoadLibrary("comctl32.dll")
+	deactivateActCtx(cookie)
+	if err != nil {
+		t.err = err
+		return
+	}
+	t.proc, t.err = windows.GetProcAddress(module, "TaskDialogIndirect")
+}
+
+func activateActCtx(ctx windows.Handle) (uintptr, error) {
+	var cookie uintptr
+	r1, _, err := Kernel32.NewProc("ActivateActCtx").Call(uintptr(ctx), uintptr(unsafe.Pointer(&cookie)))
+	if r1 == 0 {
+		return 0, err
+	}
+	return cookie, nil
+}
+
+func deactivateActCtx(cookie uintptr) {
+	Kernel32.NewProc("DeactivateActCtx").Call(0, cookie)
+}
+
+// TaskDialog shows a modal task dialog owned by owner, which may be 0, and waits until it is closed.
+// Task dialogs need comctl32 version 6; if the executable has no manifest selecting it, it is
+// loaded through an activation context so that no manifest is required.
+func TaskDialog(owner windows.HWND, cfg TaskDialogConfig) (TaskDialogResult, error) {
+	taskDialogIndirect.once.Do(loadTaskDialogIndirect)
+	if taskDialogIndirect.err != nil {
+		return TaskDialogResult{}, taskDialogIndirect.err
+	}
+
+	data, err := encodeTaskDialogConfig(owner, &cfg)
+	if err != nil {
+		return TaskDialogResult{}, err
+	}
+
+	// The activation context is per thread and must stay active while the dialog creates its controls.
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	if taskDialogIndirect.actCtx != 0 {
+		cookie, err := activateActCtx(taskDialogIndirect.actCtx)
+		if err != nil {
+			return TaskDialogResult{}, err
+		}
+		defer deactivateActCtx(cookie)
+	}
+
+	var button int32
+	var verified int32
+	r1, _, _ := syscall.SyscallN(taskDialogIndirect.proc,
+		uintptr(unsafe.Pointer(&data.config[0])),
+		uintptr(unsafe.Pointer(&button)),
+		0,
+		uintptr(unsafe.Pointer(&verified)))
+	runtime.KeepAlive(data)
+	if err := hresultError(r1); err != nil {
+		return TaskDialogResult{}, err
+	}
+	return TaskDialogResult{ButtonID: int(button), VerificationChecked: verified != 0}, nil
+}
@@ -0,0 +1,87 @@
+package win32utils
+
+import (
+	"encoding/binary"
+	"testing"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+func TestEncodeTaskDialogConfig(t *testing.T) {
+	cfg := TaskDialogConfig{
+		Title:         "title",
+		Content:       "content",
+		Buttons:       []TaskDialogButton{{ID: 100, Text: "Retry"}, {ID: 101, Text: "Skip"}},
+		CommonButtons: TDCBF_CANCEL_BUTTON,
+		MainIcon:      TD_WARNING_ICON,
+		DefaultButton: 101,
+		Flags:         TDF_ALLOW_DIALOG_CANCELLATION,
+	}
+	data, err := encodeTaskDialogConfig(0, &cfg)
+	if err != nil {
+		t.Fatalf("encodeTaskDialogConfig() error = %v", err)
+	}
+
+	ptrSize := int(unsafe.Sizeof(uintptr(0)))
+	// sizeof(TASKDIALOGCONFIG) is 160 on 64-bit and 96 on 32-bit Windows.
+	wantSize := 4*8 + ptrSize*16
+	if len(data.config) != wantSize {
+		t.Fatalf("len(config) = %d, want %d", len(data.config), wantSize)
+	}
+	if got := binary.LittleEndian.Uint32(data.config); int(got) != wantSize {
+		t.Errorf("cbSize = %d, want %d", got, wantSize)
+	}
+	readPtr := func(offset int) uintptr {
+		if ptrSize == 8 {
+			return uintptr(binary.LittleEndian.Uint64(data.config[offset:]))
+		}
+		return uintptr(binary.LittleEndian.Uint32(data.config[offset:]))
+	}
+
+	flagsOffset := 4 + 2*ptrSize
+	if got := binary.LittleEndian.Uint32(data.config[flagsOffset:]); got != cfg.Flags {
+		t.Errorf("dwFlags = %#x, want %#x", got, cfg.Flags)
+	}
+	if got := binary.LittleEndian.Uint32(data.config[flagsOffset+4:]); got != cfg.CommonButtons {
+		t.Errorf("dwCommonButtons = %#x, want %#x", got, cfg.CommonButtons)
+	}
+	titleOffset := flagsOffset + 8
+	if got := windows.UTF16PtrToString((*uint16)(unsafe.Pointer(readPtr(titleOffset)))); got != cfg.Title {
+		t.Errorf("pszWindowTitle = %q, want %q", got, cfg.Title)
+	}
+	if got := readPtr(titleOffset + ptrSize); got != cfg.MainIcon {
+		t.Errorf("hMainIcon = %#x, want %#x", got, cfg.MainIcon)
+	}
+	if got := readPtr(titleOffset + 2*ptrSize); got != 0 {
+		t.Errorf("pszMainInstruction = %#x, want 0 for an empty string", got)
+	}
+	cButtonsOffset := titleOffset + 4*ptrSize
+	if got := binary.LittleEndian.Uint32(data.config[cButtonsOffset:]); got != 2 {
+		t.Errorf("cButtons = %d, want 2", got)
+	}
+	if got := readPtr(cButtonsOffset + 4); got != uintptr(unsafe.Pointer(&data.buttons[0])) {
+		t.Errorf("pButtons = %#x, want the button array", got)
+	}
+	if got := binary.LittleEndian.Uint32(data.config[cButtonsOffset+4+ptrSize:]); got != 101 {
+		t.Errorf("nDefaultButton = %d, want 101", got)
+	}
+
+	// TASKDIALOG_BUTTON is an int followed by a pointer, without padding.
+	if len(data.buttons) != 2*(4+ptrSize) {
+		t.Fatalf("len(buttons) = %d, want %d", len(data.buttons), 2*(4+ptrSize))
+	}
+	if got := binary.LittleEndian.Uint32(data.buttons[4+ptrSize:]); got != 101 {
+		t.Errorf("second button ID = %d, want 101", got)
+	}
+}
+
+func TestLoadTaskDialogIndirect(t *testing.T) {
+	taskDialogIndirect.once.Do(loadTaskDialogIndirect)
+	if taskDialogIndirect.err != nil {
+		t.Fatalf("loading TaskDialogIndirect: %v", taskDialogIndirect.err)
+	}
+	if taskDialogIndirect.proc == 0 {
+		t.Error("TaskDialogIndirect address is 0")
+	}
+}
@@ -0,0 +1,11 @@
+package win32utils
+
+import (
+	"os"
+	"testing"
+)
+
+// TestMain is the only TestMain of the package. Package-wide setup goes here, before m.Run.
+func TestMain(m *testing.M) {
+	os.Exit(m.Run())
+}
@@ -0,0 +1,38 @@
+package win32utils
+
+import "golang.org/x/sys/windows"
+
+// GetCurrentThreadId retrieves the thread identifier of the calling thread.
+func GetCurrentThreadId() uint32 {
+	r1, _, _ := Kernel32.NewProc("GetCurrentThreadId").Call()
+	return uint32(r1)
+}
+
+// AttachThreadInput attaches or detaches the input processing of thread idAttach to that of idAttachTo.
+// While attached, the threads share keyboard focus and window activation state, so SetFocus
+// and SetForegroundWindow work across them. Both threads need a message queue.
+func AttachThreadInput(idAttach, idAttachTo uint32, attach bool) error {
+	var fAttach uintptr
+	if attach {
+		fAttach = 1
+	}
+	r1, _, err := User32.NewProc("AttachThreadInput").Call(uintptr(idAttach), uintptr(idAttachTo), fAttach)
+	if r1 == 0 {
+		if err == windows.ERROR_SUCCESS {
+			return windows.ERROR_INVALID_PARAMETER
+		}
+		return err
+	}
+	return nil
+}
+
+// WithAttachedInput attaches the input of myTID to targetTID, calls fn and detaches again,
+// even if fn returns an error or panics. The calling goroutine should be locked to the OS thread myTID.
+func WithAttachedInput(myTID, targetTID uint32, fn func() error) error {
+	err := AttachThreadInput(myTID, targetTID, true)
+	if err != nil {
+		return err
+	}
+	defer AttachThreadInput(myTID, targetTID, false)
+	return fn()
+}
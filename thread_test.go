@@ -0,0 +1,41 @@
+package win32utils
+
+import (
+	"runtime"
+	"testing"
+)
+
+// ensureMessageQueue converts the calling thread to a GUI thread so that it has a message queue.
+func ensureMessageQueue() {
+	_, _, _ = User32.NewProc("IsGUIThread").Call(1)
+}
+
+func TestAttachThreadInput(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	ensureMessageQueue()
+
+	target := make(chan uint32)
+	done := make(chan struct{})
+	go func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		ensureMessageQueue()
+		target <- GetCurrentThreadId()
+		<-done
+	}()
+	defer close(done)
+	targetTID := <-target
+
+	called := false
+	err := WithAttachedInput(GetCurrentThreadId(), targetTID, func() error {
+		called = true
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WithAttachedInput() error = %v", err)
+	}
+	if !called {
+		t.Error("WithAttachedInput() did not call fn")
+	}
+}
@@ -0,0 +1,23 @@
+package win32utils
+
+import "golang.org/x/sys/windows"
+
+// SetTimer creates or replaces the timer idEvent of hwnd, which fires every elapse milliseconds.
+// If proc is 0, WM_TIMER with wParam idEvent is posted to hwnd; otherwise the TIMERPROC callback proc
+// is called by DispatchMessageW. With hwnd 0 the system chooses the identifier, which is returned.
+func SetTimer(hwnd windows.HWND, idEvent uintptr, elapse uint32, proc uintptr) (uintptr, error) {
+	r1, _, err := User32.NewProc("SetTimer").Call(uintptr(hwnd), idEvent, uintptr(elapse), proc)
+	if r1 == 0 {
+		return 0, err
+	}
+	return r1, nil
+}
+
+// KillTimer destroys the timer idEvent of hwnd. WM_TIMER messages already posted are not removed.
+func KillTimer(hwnd windows.HWND, idEvent uintptr) error {
+	r1, _, err := User32.NewProc("KillTimer").Call(uintptr(hwnd), idEvent)
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package win32utils
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestSetTimer(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	hwnd, err := CreateWindowExW(0, "STATIC", "", WS_POPUP, 0, 0, 10, 10, HWND_MESSAGE, 0, moduleHandle(), 0)
+	if err != nil {
+		t.Fatalf("CreateWindowExW() error = %v", err)
+	}
+	defer DestroyWindow(hwnd)
+
+	id, err := SetTimer(hwnd, 1, 10, 0)
+	if err != nil {
+		t.Fatalf("SetTimer() error = %v", err)
+	}
+	if id != 1 {
+		t.Errorf("SetTimer() = %d, want 1", id)
+	}
+
+	fired := false
+	deadline := time.Now().Add(2 * time.Second)
+	for !fired && time.Now().Before(deadline) {
+		var msg MSG
+		if PeekMessageW(&msg, hwnd, WM_TIMER, WM_TIMER, PM_REMOVE) {
+			fired = msg.WParam == id
+			continue
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if !fired {
+		t.Fatal("WM_TIMER not received")
+	}
+
+	if err := KillTimer(hwnd, id); err != nil {
+		t.Fatalf("KillTimer() error = %v", err)
+	}
+	if err := KillTimer(hwnd, id); err == nil {
+		t.Error("KillTimer() on a destroyed timer succeeded")
+	}
+}
@@ -0,0 +1,130 @@
+package win32utils
+
+import (
+	"errors"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// TOOLTIPS_CLASS is the window class name of the tooltip common control.
+const TOOLTIPS_CLASS = "tooltips_class32"
+
+// Tooltip control styles.
+const (
+	TTS_ALWAYSTIP uint32 = 0x01
+	TTS_NOPREFIX  uint32 = 0x02
+)
+
+// TOOLINFOW flags.
+const (
+	TTF_IDISHWND uint32 = 0x0001
+	TTF_SUBCLASS uint32 = 0x0010
+)
+
+// Tooltip control messages.
+const (
+	TTM_ADDTOOLW       = WM_USER + 50
+	TTM_UPDATETIPTEXTW = WM_USER + 57
+)
+
+// ICC_BAR_CLASSES registers the toolbar, status bar, trackbar and tooltip classes.
+const ICC_BAR_CLASSES uint32 = 0x00000004
+
+// INITCOMMONCONTROLSEX selects the common control classes to register in InitCommonControlsEx.
+type INITCOMMONCONTROLSEX struct {
+	DwSize uint32
+	DwICC  uint32
+}
+
+// TOOLINFOW describes a tool of a tooltip control. The layout stops before lpReserved
+// (TTTOOLINFOW_V2_SIZE), so it is accepted by both comctl32 version 5 and version 6.
+type TOOLINFOW struct {
+	CbSize   uint32
+	UFlags   uint32
+	Hwnd     windows.HWND
+	UId      uintptr
+	Rect     RECT
+	Hinst    windows.Handle
+	LpszText *uint16
+	LParam   uintptr
+}
+
+// InitCommonControlsEx registers the common control classes selected by icc.
+func InitCommonControlsEx(icc uint32) error {
+	init := INITCOMMONCONTROLSEX{DwICC: icc}
+	init.DwSize = uint32(unsafe.Sizeof(init))
+	r1, _, err := Comctl32.NewProc("InitCommonControlsEx").Call(uintptr(unsafe.Pointer(&init)))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+var errAddTool = errors.New("win32utils: TTM_ADDTOOLW failed")
+
+// Tooltip is a tooltip control that shows hover text for child windows.
+// All methods must be called from the thread that created the tooltip.
+type Tooltip struct {
+	hwnd   windows.HWND
+	parent windows.HWND
+}
+
+// NewTooltip creates a tooltip control owned by parent.
+func NewTooltip(parent windows.HWND) (*Tooltip, error) {
+	err := InitCommonControlsEx(ICC_BAR_CLASSES)
+	if err != nil {
+		return nil, err
+	}
+
+	hwnd, err := CreateWindowExW(WS_EX_TOPMOST, TOOLTIPS_CLASS, "", WS_POPUP|TTS_ALWAYSTIP|TTS_NOPREFIX,
+		CW_USEDEFAULT, CW_USEDEFAULT, CW_USEDEFAULT, CW_USEDEFAULT,
+		parent, 0, moduleHandle(), 0)
+	if err != nil {
+		return nil, err
+	}
+	return &Tooltip{hwnd: hwnd, parent: parent}, nil
+}
+
+// AddTool shows text when the mouse hovers over hwnd, which is usually a child window of the tooltip's parent.
+func (t *Tooltip) AddTool(hwnd windows.HWND, text string) error {
+	textPtr, err := windows.UTF16PtrFromString(text)
+	if err != nil {
+		return err
+	}
+
+	info := t.toolInfo(hwnd)
+	info.UFlags = TTF_IDISHWND | TTF_SUBCLASS
+	info.LpszText = textPtr
+	if SendMessageW(t.hwnd, TTM_ADDTOOLW, 0, uintptr(unsafe.Pointer(&info))) == 0 {
+		return errAddTool
+	}
+	return nil
+}
+
+// SetText replaces the text shown for a tool previously added with AddTool.
+func (t *Tooltip) SetText(hwnd windows.HWND, text string) error {
+	textPtr, err := windows.UTF16PtrFromString(text)
+	if err != nil {
+		return err
+	}
+
+	info := t.toolInfo(hwnd)
+	info.LpszText = textPtr
+	SendMessageW(t.hwnd, TTM_UPDATETIPTEXTW, 0, uintptr(unsafe.Pointer(&info)))
+	return nil
+}
+
+// Destroy destroys the tooltip control.
+func (t *Tooltip) Destroy() error {
+	return DestroyWindow(t.hwnd)
+}
+
+func (t *Tooltip) toolInfo(hwnd windows.HWND) TOOLINFOW {
+	info := TOOLINFOW{
+		Hwnd: t.parent,
+		UId:  uintptr(hwnd),
+	}
+	info.CbSize = uint32(unsafe.Sizeof(info))
+	return info
+}
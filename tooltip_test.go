@@ -0,0 +1,30 @@
+package win32utils
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestTooltip(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	parent, err := CreateWindowExW(0, "STATIC", "", 0, 0, 0, 100, 100, 0, 0, moduleHandle(), 0)
+	if err != nil {
+		t.Fatalf("CreateWindowExW() error = %v", err)
+	}
+	defer DestroyWindow(parent)
+
+	tip, err := NewTooltip(parent)
+	if err != nil {
+		t.Fatalf("NewTooltip() error = %v", err)
+	}
+	defer tip.Destroy()
+
+	if err := tip.AddTool(parent, "hello"); err != nil {
+		t.Fatalf("AddTool() error = %v", err)
+	}
+	if err := tip.SetText(parent, "world"); err != nil {
+		t.Errorf("SetText() error = %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package win32utils
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Feedback types for SetWindowFeedbackSetting.
+const (
+	FEEDBACK_TOUCH_CONTACTVISUALIZATION uint32 = 1
+	FEEDBACK_PEN_BARRELVISUALIZATION    uint32 = 2
+	FEEDBACK_PEN_TAP                    uint32 = 3
+	FEEDBACK_PEN_DOUBLETAP              uint32 = 4
+	FEEDBACK_PEN_PRESSANDHOLD           uint32 = 5
+	FEEDBACK_PEN_RIGHTTAP               uint32 = 6
+	FEEDBACK_TOUCH_TAP                  uint32 = 7
+	FEEDBACK_TOUCH_DOUBLETAP            uint32 = 8
+	FEEDBACK_TOUCH_PRESSANDHOLD         uint32 = 9
+	FEEDBACK_TOUCH_RIGHTTAP             uint32 = 10
+	FEEDBACK_GESTURES_PRESSANDTAP       uint32 = 11
+	FEEDBACK_MAX                        uint32 = 0xFFFFFFFF
+)
+
+// SetWindowFeedbackSetting enables (configuration 1) or disables (configuration 0) one kind of
+// visual feedback for touch and pen input on hwnd. It requires Windows 8 or later.
+func SetWindowFeedbackSetting(hwnd windows.HWND, feedback uint32, flags uint32, configuration uint32) error {
+	proc := User32.NewProc("SetWindowFeedbackSetting")
+	if err := proc.Find(); err != nil {
+		return err
+	}
+	r1, _, err := proc.Call(uintptr(hwnd), uintptr(feedback), uintptr(flags),
+		unsafe.Sizeof(configuration), uintptr(unsafe.Pointer(&configuration)))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// DisableTouchFeedback turns off every kind of touch and pen feedback, such as the contact circles, for hwnd.
+func DisableTouchFeedback(hwnd windows.HWND) error {
+	for feedback := FEEDBACK_TOUCH_CONTACTVISUALIZATION; feedback <= FEEDBACK_GESTURES_PRESSANDTAP; feedback++ {
+		err := SetWindowFeedbackSetting(hwnd, feedback, 0, 0)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
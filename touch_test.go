@@ -0,0 +1,22 @@
+package win32utils
+
+import (
+	"runtime"
+	"testing"
+)
+
+// The feedback circles themselves have to be checked manually on a touch screen.
+func TestDisableTouchFeedback(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	hwnd, err := CreateWindowExW(0, "STATIC", "", 0, 0, 0, 0, 0, HWND_MESSAGE, 0, moduleHandle(), 0)
+	if err != nil {
+		t.Fatalf("CreateWindowExW() error = %v", err)
+	}
+	defer DestroyWindow(hwnd)
+
+	if err := DisableTouchFeedback(hwnd); err != nil {
+		t.Errorf("DisableTouchFeedback() error = %v", err)
+	}
+}
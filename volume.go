@@ -0,0 +1,78 @@
+package win32utils
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// GetDiskFreeSpaceExW retrieves the amount of space on the volume containing dir.
+// available is the free space available to the calling user, which can be less than totalFree
+// when disk quotas are in effect.
+func GetDiskFreeSpaceExW(dir string) (available, totalBytes, totalFree uint64, err error) {
+	dirPtr, err := windows.UTF16PtrFromString(dir)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	r1, _, err := Kernel32.NewProc("GetDiskFreeSpaceExW").Call(
+		uintptr(unsafe.Pointer(dirPtr)),
+		uintptr(unsafe.Pointer(&available)),
+		uintptr(unsafe.Pointer(&totalBytes)),
+		uintptr(unsafe.Pointer(&totalFree)))
+	if r1 == 0 {
+		return 0, 0, 0, err
+	}
+	return available, totalBytes, totalFree, nil
+}
+
+// DriveFreeSpaceGB returns the free space available to the calling user on a drive, in GiB.
+func DriveFreeSpaceGB(driveLetter byte) (float64, error) {
+	available, _, _, err := GetDiskFreeSpaceExW(string(driveLetter) + `:\`)
+	if err != nil {
+		return 0, err
+	}
+	return float64(available) / (1 << 30), nil
+}
+
+// File system flags returned by GetVolumeInformationW.
+const (
+	FILE_CASE_SENSITIVE_SEARCH   uint32 = 0x00000001
+	FILE_CASE_PRESERVED_NAMES    uint32 = 0x00000002
+	FILE_UNICODE_ON_DISK         uint32 = 0x00000004
+	FILE_PERSISTENT_ACLS         uint32 = 0x00000008
+	FILE_FILE_COMPRESSION        uint32 = 0x00000010
+	FILE_VOLUME_QUOTAS           uint32 = 0x00000020
+	FILE_SUPPORTS_SPARSE_FILES   uint32 = 0x00000040
+	FILE_SUPPORTS_REPARSE_POINTS uint32 = 0x00000080
+	FILE_VOLUME_IS_COMPRESSED    uint32 = 0x00008000
+	FILE_SUPPORTS_OBJECT_IDS     uint32 = 0x00010000
+	FILE_SUPPORTS_ENCRYPTION     uint32 = 0x00020000
+	FILE_NAMED_STREAMS           uint32 = 0x00040000
+	FILE_READ_ONLY_VOLUME        uint32 = 0x00080000
+)
+
+// GetVolumeInformationW retrieves information about the file system and volume rooted at rootPath,
+// which must end with a backslash, e.g. `C:\`.
+func GetVolumeInformationW(rootPath string) (volumeName string, serialNumber uint32, maxComponentLen uint32, fsFlags uint32, fsName string, err error) {
+	rootPtr, err := windows.UTF16PtrFromString(rootPath)
+	if err != nil {
+		return "", 0, 0, 0, "", err
+	}
+
+	nameBuf := make([]uint16, windows.MAX_PATH+1)
+	fsBuf := make([]uint16, windows.MAX_PATH+1)
+	r1, _, err := Kernel32.NewProc("GetVolumeInformationW").Call(
+		uintptr(unsafe.Pointer(rootPtr)),
+		uintptr(unsafe.Pointer(&nameBuf[0])),
+		uintptr(len(nameBuf)),
+		uintptr(unsafe.Pointer(&serialNumber)),
+		uintptr(unsafe.Pointer(&maxComponentLen)),
+		uintptr(unsafe.Pointer(&fsFlags)),
+		uintptr(unsafe.Pointer(&fsBuf[0])),
+		uintptr(len(fsBuf)))
+	if r1 == 0 {
+		return "", 0, 0, 0, "", err
+	}
+	return windows.UTF16ToString(nameBuf), serialNumber, maxComponentLen, fsFlags, windows.UTF16ToString(fsBuf), nil
+}
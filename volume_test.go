@@ -0,0 +1,38 @@
+package win32utils
+
+import "testing"
+
+func TestGetDiskFreeSpaceExW(t *testing.T) {
+	available, totalBytes, totalFree, err := GetDiskFreeSpaceExW(`C:\`)
+	if err != nil {
+		t.Fatalf("GetDiskFreeSpaceExW() error = %v", err)
+	}
+	if available == 0 || totalBytes == 0 || totalFree == 0 {
+		t.Errorf("GetDiskFreeSpaceExW() = %d, %d, %d, want all non-zero", available, totalBytes, totalFree)
+	}
+	if available > totalBytes {
+		t.Errorf("available = %d, want at most totalBytes = %d", available, totalBytes)
+	}
+
+	gb, err := DriveFreeSpaceGB('C')
+	if err != nil {
+		t.Fatalf("DriveFreeSpaceGB() error = %v", err)
+	}
+	if gb <= 0 {
+		t.Errorf("DriveFreeSpaceGB() = %v, want > 0", gb)
+	}
+}
+
+func TestGetVolumeInformationW(t *testing.T) {
+	// The volume label may be empty, so only the file system is checked.
+	_, _, maxComponentLen, _, fsName, err := GetVolumeInformationW(`C:\`)
+	if err != nil {
+		t.Fatalf("GetVolumeInformationW() error = %v", err)
+	}
+	if fsName != "NTFS" && fsName != "ReFS" {
+		t.Errorf("fsName = %q, want NTFS or ReFS", fsName)
+	}
+	if maxComponentLen == 0 {
+		t.Error("maxComponentLen = 0, want > 0")
+	}
+}
@@ -5,25 +5,44 @@ import "golang.org/x/sys/windows"
 const GMEM_MOVEABLE uintptr = 0x0002
 
 func GlobalAlloc(flags uint, size uint) (handle windows.Handle, err error) {
-	r1, _, _ := Kernel32.NewProc("GlobalAlloc").Call(uintptr(flags), uintptr(size))
+	r1, _, err := Kernel32.NewProc("GlobalAlloc").Call(uintptr(flags), uintptr(size))
 	if r1 == 0 {
-		return 0, windows.GetLastError()
+		return 0, err
 	}
 	return windows.Handle(r1), nil
 }
 
 func GlobalLock(hMem windows.Handle) (pointer uintptr, err error) {
-	r1, _, _ := Kernel32.NewProc("GlobalLock").Call(uintptr(hMem))
+	r1, _, err := Kernel32.NewProc("GlobalLock").Call(uintptr(hMem))
 	if r1 == 0 {
-		return 0, windows.GetLastError()
+		return 0, err
 	}
 	return r1, nil
 }
 
-func GlobalUnlock(hMem windows.Handle) (err error) {
-	r1, _, _ := Kernel32.NewProc("GlobalUnlock").Call(uintptr(hMem))
+func GlobalSize(hMem windows.Handle) (size uint, err error) {
+	r1, _, err := Kernel32.NewProc("GlobalSize").Call(uintptr(hMem))
 	if r1 == 0 {
-		return windows.GetLastError()
+		return 0, err
+	}
+	return uint(r1), nil
+}
+
+// GlobalUnlock decrements the lock count of a moveable memory object.
+// GlobalUnlock returns zero both on failure and when the lock count drops to zero,
+// so only a zero result with a last error is reported as an error.
+func GlobalUnlock(hMem windows.Handle) (err error) {
+	r1, _, err := Kernel32.NewProc("GlobalUnlock").Call(uintptr(hMem))
+	if r1 == 0 && err != windows.ERROR_SUCCESS {
+		return err
+	}
+	return nil
+}
+
+func GlobalFree(hMem windows.Handle) (err error) {
+	r1, _, err := Kernel32.NewProc("GlobalFree").Call(uintptr(hMem))
+	if r1 != 0 {
+		return err
 	}
 	return nil
 }
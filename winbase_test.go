@@ -0,0 +1,21 @@
+package win32utils
+
+import "testing"
+
+func TestGlobalUnlock(t *testing.T) {
+	h, err := GlobalAlloc(uint(GMEM_MOVEABLE), 16)
+	if err != nil {
+		t.Fatalf("GlobalAlloc() error = %v", err)
+	}
+	defer GlobalFree(h)
+
+	if _, err := GlobalLock(h); err != nil {
+		t.Fatalf("GlobalLock() error = %v", err)
+	}
+	if err := GlobalUnlock(h); err != nil {
+		t.Errorf("GlobalUnlock() error = %v, want nil when the lock count drops to zero", err)
+	}
+	if err := GlobalUnlock(h); err == nil {
+		t.Error("GlobalUnlock() of an unlocked block succeeded, want ERROR_NOT_LOCKED")
+	}
+}
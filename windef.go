@@ -0,0 +1,25 @@
+package win32utils
+
+// RECT defines a rectangle by the coordinates of its upper-left and lower-right corners.
+type RECT struct {
+	Left   int32
+	Top    int32
+	Right  int32
+	Bottom int32
+}
+
+// POINT defines the x- and y-coordinates of a point.
+type POINT struct {
+	X int32
+	Y int32
+}
+
+// MinMaxInfo mirrors MINMAXINFO, which carries the maximized size and position
+// and the minimum and maximum tracking sizes of a window in WM_GETMINMAXINFO.
+type MinMaxInfo struct {
+	PtReserved     POINT
+	PtMaxSize      POINT
+	PtMaxPosition  POINT
+	PtMinTrackSize POINT
+	PtMaxTrackSize POINT
+}
@@ -0,0 +1,292 @@
+package win32utils
+
+import (
+	"errors"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// GetClientRect retrieves the coordinates of a window's client area.
+// Left and Top are always zero, so Right and Bottom hold the width and height.
+func GetClientRect(hwnd windows.HWND) (RECT, error) {
+	var rect RECT
+	r1, _, err := User32.NewProc("GetClientRect").Call(uintptr(hwnd), uintptr(unsafe.Pointer(&rect)))
+	if r1 == 0 {
+		return RECT{}, err
+	}
+	return rect, nil
+}
+
+// GetWindowRect retrieves the bounding rectangle of a window in screen coordinates.
+func GetWindowRect(hwnd windows.HWND) (RECT, error) {
+	var rect RECT
+	r1, _, err := User32.NewProc("GetWindowRect").Call(uintptr(hwnd), uintptr(unsafe.Pointer(&rect)))
+	if r1 == 0 {
+		return RECT{}, err
+	}
+	return rect, nil
+}
+
+// MoveWindow changes the position and size of a window. Coordinates of child windows are
+// relative to the parent's client area.
+func MoveWindow(hwnd windows.HWND, x, y, width, height int32, repaint bool) error {
+	var bRepaint uintptr
+	if repaint {
+		bRepaint = 1
+	}
+	r1, _, err := User32.NewProc("MoveWindow").Call(uintptr(hwnd), uintptr(x), uintptr(y), uintptr(width), uintptr(height), bRepaint)
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// SetWindowPos changes the size, position and Z order of a window. insertAfter is a window or
+// one of the HWND_ values; flags are SWP_ values.
+func SetWindowPos(hwnd windows.HWND, insertAfter windows.HWND, x, y, cx, cy int32, flags uint32) error {
+	r1, _, err := User32.NewProc("SetWindowPos").Call(uintptr(hwnd), uintptr(insertAfter),
+		uintptr(x), uintptr(y), uintptr(cx), uintptr(cy), uintptr(flags))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// ClientRectSize returns the width and height of a window's client area.
+func ClientRectSize(hwnd windows.HWND) (width, height int32, err error) {
+	rect, err := GetClientRect(hwnd)
+	if err != nil {
+		return 0, 0, err
+	}
+	return rect.Right - rect.Left, rect.Bottom - rect.Top, nil
+}
+
+// GetFocus retrieves the handle to the window that has the keyboard focus,
+// if the window is attached to the calling thread's message queue.
+func GetFocus() windows.HWND {
+	r1, _, _ := User32.NewProc("GetFocus").Call()
+	return windows.HWND(r1)
+}
+
+// SetFocus sets the keyboard focus to the specified window and returns
+// the window that previously had the keyboard focus.
+func SetFocus(hwnd windows.HWND) (windows.HWND, error) {
+	r1, _, err := User32.NewProc("SetFocus").Call(uintptr(hwnd))
+	if r1 == 0 && err != windows.ERROR_SUCCESS {
+		return 0, err
+	}
+	return windows.HWND(r1), nil
+}
+
+// Window attribute offsets for GetWindowLongPtrW and SetWindowLongPtrW.
+const (
+	GWL_WNDPROC  int32 = -4
+	GWLP_WNDPROC int32 = GWL_WNDPROC
+	GWL_ID       int32 = -12
+	GWL_STYLE    int32 = -16
+	GWL_EXSTYLE  int32 = -20
+	GWL_USERDATA int32 = -21
+)
+
+// Special window handles. HWND_MESSAGE is the parent of message-only windows;
+// the others are insertAfter values for SetWindowPos.
+const (
+	HWND_TOP       = windows.HWND(0)
+	HWND_BOTTOM    = windows.HWND(1)
+	HWND_TOPMOST   = ^windows.HWND(0)
+	HWND_NOTOPMOST = ^windows.HWND(1)
+	HWND_MESSAGE   = ^windows.HWND(2)
+)
+
+// Flags for SetWindowPos.
+const (
+	SWP_NOSIZE         uint32 = 0x0001
+	SWP_NOMOVE         uint32 = 0x0002
+	SWP_NOZORDER       uint32 = 0x0004
+	SWP_NOREDRAW       uint32 = 0x0008
+	SWP_NOACTIVATE     uint32 = 0x0010
+	SWP_FRAMECHANGED   uint32 = 0x0020
+	SWP_DRAWFRAME      uint32 = SWP_FRAMECHANGED
+	SWP_SHOWWINDOW     uint32 = 0x0040
+	SWP_HIDEWINDOW     uint32 = 0x0080
+	SWP_NOCOPYBITS     uint32 = 0x0100
+	SWP_NOOWNERZORDER  uint32 = 0x0200
+	SWP_NOREPOSITION   uint32 = SWP_NOOWNERZORDER
+	SWP_NOSENDCHANGING uint32 = 0x0400
+	SWP_DEFERERASE     uint32 = 0x2000
+	SWP_ASYNCWINDOWPOS uint32 = 0x4000
+)
+
+// CW_USEDEFAULT selects the default position or size in CreateWindowExW.
+const CW_USEDEFAULT int32 = -0x80000000
+
+// WNDCLASSEXW contains window class information used by RegisterClassExW.
+type WNDCLASSEXW struct {
+	CbSize        uint32
+	Style         uint32
+	LpfnWndProc   uintptr
+	CbClsExtra    int32
+	CbWndExtra    int32
+	HInstance     windows.Handle
+	HIcon         windows.Handle
+	HCursor       windows.Handle
+	HbrBackground windows.Handle
+	LpszMenuName  *uint16
+	LpszClassName *uint16
+	HIconSm       windows.Handle
+}
+
+// registerClassExW registers a window class that uses globalWndProc and paints its background
+// in the button-face color. Registering a class that already exists is not an error.
+func registerClassExW(className string) error {
+	namePtr, err := windows.UTF16PtrFromString(className)
+	if err != nil {
+		return err
+	}
+
+	class := WNDCLASSEXW{
+		LpfnWndProc:   globalWndProcCallback,
+		HInstance:     moduleHandle(),
+		HbrBackground: windows.Handle(COLOR_BTNFACE + 1),
+		LpszClassName: namePtr,
+	}
+	class.CbSize = uint32(unsafe.Sizeof(class))
+
+	r1, _, err := User32.NewProc("RegisterClassExW").Call(uintptr(unsafe.Pointer(&class)))
+	if r1 == 0 {
+		if err == windows.ERROR_CLASS_ALREADY_EXISTS {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// Errors returned by CreateWindowExW in place of the corresponding system error codes.
+var (
+	ErrClassNotFound       = errors.New("win32utils: window class does not exist")
+	ErrInvalidWindowHandle = errors.New("win32utils: invalid window handle")
+)
+
+// CreateWindowExW of Win32 API. Check https://learn.microsoft.com/en-us/windows/win32/api/winuser/nf-winuser-createwindowexw for more detail.
+func CreateWindowExW(exStyle uint32, className, windowName string, style uint32, x, y, width, height int32,
+	parent windows.HWND, menu, instance windows.Handle, param uintptr) (windows.HWND, error) {
+	classPtr, err := windows.UTF16PtrFromString(className)
+	if err != nil {
+		return 0, err
+	}
+	namePtr, err := windows.UTF16PtrFromString(windowName)
+	if err != nil {
+		return 0, err
+	}
+
+	r1, _, err := User32.NewProc("CreateWindowExW").Call(
+		uintptr(exStyle),
+		uintptr(unsafe.Pointer(classPtr)),
+		uintptr(unsafe.Pointer(namePtr)),
+		uintptr(style),
+		uintptr(x),
+		uintptr(y),
+		uintptr(width),
+		uintptr(height),
+		uintptr(parent),
+		uintptr(menu),
+		uintptr(instance),
+		param)
+	if r1 == 0 {
+		switch err {
+		case windows.ERROR_CANNOT_FIND_WND_CLASS, windows.ERROR_CLASS_DOES_NOT_EXIST:
+			return 0, ErrClassNotFound
+		case windows.ERROR_INVALID_WINDOW_HANDLE:
+			// The parent window does not exist.
+			return 0, ErrInvalidWindowHandle
+		}
+		return 0, err
+	}
+	return windows.HWND(r1), nil
+}
+
+// DestroyWindow destroys the specified window. It must be called from the thread that created the window.
+func DestroyWindow(hwnd windows.HWND) error {
+	r1, _, err := User32.NewProc("DestroyWindow").Call(uintptr(hwnd))
+	if r1 == 0 {
+		return err
+	}
+	return nil
+}
+
+// createManagedWindow registers className if needed, creates a window of that class
+// and routes its messages to proc. Messages sent during CreateWindowExW itself,
+// such as WM_CREATE, are handled by DefWindowProcW.
+func createManagedWindow(className string, exStyle, style uint32, parent windows.HWND, proc WndProc) (windows.HWND, error) {
+	err := registerClassExW(className)
+	if err != nil {
+		return 0, err
+	}
+
+	hwnd, err := CreateWindowExW(exStyle, className, "", style,
+		CW_USEDEFAULT, CW_USEDEFAULT, CW_USEDEFAULT, CW_USEDEFAULT,
+		parent, 0, moduleHandle(), 0)
+	if err != nil {
+		return 0, err
+	}
+
+	wndProcMu.Lock()
+	wndProcByHWND[hwnd] = proc
+	wndProcMu.Unlock()
+	return hwnd, nil
+}
+
+func moduleHandle() windows.Handle {
+	r1, _, _ := Kernel32.NewProc("GetModuleHandleW").Call(0)
+	return windows.Handle(r1)
+}
+
+// windowLongProc returns the name of the 64-bit export, or of its 32-bit counterpart:
+// 32-bit user32.dll does not export the ...LongPtrW functions, which are macros there.
+func windowLongProc(name64, name32 string) string {
+	if unsafe.Sizeof(uintptr(0)) == 4 {
+		return name32
+	}
+	return name64
+}
+
+// GetWindowLongPtrW retrieves the window attribute at index, one of the GWL_ values.
+// On 32-bit Windows it calls GetWindowLongW.
+func GetWindowLongPtrW(hwnd windows.HWND, index int32) (uintptr, error) {
+	r1, _, err := User32.NewProc(windowLongProc("GetWindowLongPtrW", "GetWindowLongW")).Call(uintptr(hwnd), uintptr(index))
+	if r1 == 0 && err != windows.ERROR_SUCCESS {
+		return 0, err
+	}
+	return r1, nil
+}
+
+// SetWindowLongPtrW changes the window attribute at index, one of the GWL_ values, and returns its previous value.
+// Style changes take effect after SetWindowPos with SWP_FRAMECHANGED; SetWindowStyle does both.
+// On 32-bit Windows it calls SetWindowLongW.
+func SetWindowLongPtrW(hwnd windows.HWND, index int32, newLong uintptr) (uintptr, error) {
+	r1, _, err := User32.NewProc(windowLongProc("SetWindowLongPtrW", "SetWindowLongW")).Call(uintptr(hwnd), uintptr(index), newLong)
+	if r1 == 0 && err != windows.ERROR_SUCCESS {
+		return 0, err
+	}
+	return r1, nil
+}
+
+// GetWindowStyle retrieves the WS_ styles of hwnd.
+func GetWindowStyle(hwnd windows.HWND) (WindowStyle, error) {
+	style, err := GetWindowLongPtrW(hwnd, GWL_STYLE)
+	if err != nil {
+		return WindowStyle{}, err
+	}
+	return WindowStyle{Bits: WindowStyleBits(style)}, nil
+}
+
+// SetWindowStyle replaces the WS_ styles of hwnd and redraws its frame so that the change takes effect.
+func SetWindowStyle(hwnd windows.HWND, style WindowStyle) error {
+	_, err := SetWindowLongPtrW(hwnd, GWL_STYLE, uintptr(style.Bits))
+	if err != nil {
+		return err
+	}
+	return SetWindowPos(hwnd, 0, 0, 0, 0, 0, SWP_FRAMECHANGED|SWP_NOMOVE|SWP_NOSIZE|SWP_NOZORDER|SWP_NOACTIVATE)
+}
@@ -0,0 +1,188 @@
+package win32utils
+
+import (
+	"runtime"
+	"slices"
+	"testing"
+
+	"golang.org/x/sys/windows"
+)
+
+func TestCreateWindowExWClassNotFound(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	hwnd, err := CreateWindowExW(0, "win32utils_test_unregistered", "", 0, 0, 0, 0, 0, HWND_MESSAGE, 0, moduleHandle(), 0)
+	if err != ErrClassNotFound {
+		if err == nil {
+			DestroyWindow(hwnd)
+		}
+		t.Errorf("CreateWindowExW() error = %v, want ErrClassNotFound", err)
+	}
+}
+
+func TestEnumWindows(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	parent, err := CreateWindowExW(0, "STATIC", "win32utils EnumWindows test", WS_POPUP, 0, 0, 10, 10, 0, 0, moduleHandle(), 0)
+	if err != nil {
+		t.Fatalf("CreateWindowExW() error = %v", err)
+	}
+	defer DestroyWindow(parent)
+	child, err := CreateWindowExW(0, "STATIC", "", WS_CHILD, 0, 0, 5, 5, parent, 0, moduleHandle(), 0)
+	if err != nil {
+		t.Fatalf("CreateWindowExW() error = %v", err)
+	}
+
+	top, err := EnumWindows()
+	if err != nil {
+		t.Fatalf("EnumWindows() error = %v", err)
+	}
+	children, err := EnumChildWindows(parent)
+	if err != nil {
+		t.Fatalf("EnumChildWindows() error = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		list  []windows.HWND
+		hwnd  windows.HWND
+		found bool
+	}{
+		{"console window", top, windows.HWND(GetConsoleWindows()), true},
+		{"top-level window", top, parent, true},
+		{"child not top-level", top, child, false},
+		{"child of parent", children, child, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.hwnd == 0 {
+				t.Skip("no console window")
+			}
+			if got := slices.Contains(tt.list, tt.hwnd); got != tt.found {
+				t.Errorf("list contains 0x%X = %v, want %v", tt.hwnd, got, tt.found)
+			}
+		})
+	}
+
+	got, err := FindWindowByTitle("ENUMWINDOWS TEST")
+	if err != nil {
+		t.Fatalf("FindWindowByTitle() error = %v", err)
+	}
+	if got != parent {
+		t.Errorf("FindWindowByTitle() = 0x%X, want 0x%X", got, parent)
+	}
+}
+
+func TestGetClientRect(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	hwnd, err := CreateWindowExW(0, "STATIC", "", WS_POPUP, 0, 0, 100, 50, 0, 0, moduleHandle(), 0)
+	if err != nil {
+		t.Fatalf("CreateWindowExW() error = %v", err)
+	}
+	defer DestroyWindow(hwnd)
+
+	rect, err := GetClientRect(hwnd)
+	if err != nil {
+		t.Fatalf("GetClientRect() error = %v", err)
+	}
+	if rect.Left != 0 || rect.Top != 0 || rect.Right <= 0 || rect.Bottom <= 0 {
+		t.Errorf("GetClientRect() = %v, want origin 0,0 and a non-empty size", rect)
+	}
+
+	width, height, err := ClientRectSize(hwnd)
+	if err != nil {
+		t.Fatalf("ClientRectSize() error = %v", err)
+	}
+	if width != rect.Right || height != rect.Bottom {
+		t.Errorf("ClientRectSize() = %d, %d, want %d, %d", width, height, rect.Right, rect.Bottom)
+	}
+}
+
+func TestSetFocus(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	parent, err := CreateWindowExW(0, "STATIC", "", WS_POPUP, 0, 0, 100, 50, 0, 0, moduleHandle(), 0)
+	if err != nil {
+		t.Fatalf("CreateWindowExW() error = %v", err)
+	}
+	defer DestroyWindow(parent)
+	var edits [2]windows.HWND
+	for i := range edits {
+		edits[i], err = CreateWindowExW(0, "EDIT", "", WS_CHILD|WS_VISIBLE|WS_TABSTOP, 0, int32(i)*20, 100, 20, parent, 0, moduleHandle(), 0)
+		if err != nil {
+			t.Fatalf("CreateWindowExW() error = %v", err)
+		}
+	}
+	a, b := edits[0], edits[1]
+
+	if _, err := SetFocus(a); err != nil {
+		t.Fatalf("SetFocus(a) error = %v", err)
+	}
+	if got := GetFocus(); got != a {
+		t.Errorf("GetFocus() = 0x%X, want 0x%X", got, a)
+	}
+	prev, err := SetFocus(b)
+	if err != nil {
+		t.Fatalf("SetFocus(b) error = %v", err)
+	}
+	if prev != a {
+		t.Errorf("SetFocus(b) = 0x%X, want previous focus 0x%X", prev, a)
+	}
+}
+
+func TestSetWindowPos(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	hwnd, err := CreateWindowExW(0, "STATIC", "", WS_POPUP, 0, 0, 10, 10, 0, 0, moduleHandle(), 0)
+	if err != nil {
+		t.Fatalf("CreateWindowExW() error = %v", err)
+	}
+	defer DestroyWindow(hwnd)
+
+	if err := MoveWindow(hwnd, 10, 20, 100, 50, false); err != nil {
+		t.Fatalf("MoveWindow() error = %v", err)
+	}
+	if got, _ := GetWindowRect(hwnd); got != (RECT{10, 20, 110, 70}) {
+		t.Errorf("GetWindowRect() after MoveWindow = %v, want %v", got, RECT{10, 20, 110, 70})
+	}
+
+	if err := SetWindowPos(hwnd, HWND_TOPMOST, 30, 40, 0, 0, SWP_NOSIZE|SWP_NOACTIVATE); err != nil {
+		t.Fatalf("SetWindowPos() error = %v", err)
+	}
+	if got, _ := GetWindowRect(hwnd); got != (RECT{30, 40, 130, 90}) {
+		t.Errorf("GetWindowRect() after SetWindowPos = %v, want %v", got, RECT{30, 40, 130, 90})
+	}
+}
+
+func TestWindowStyle(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	hwnd, err := CreateWindowExW(0, "STATIC", "", WS_POPUP, 0, 0, 10, 10, 0, 0, moduleHandle(), 0)
+	if err != nil {
+		t.Fatalf("CreateWindowExW() error = %v", err)
+	}
+	defer DestroyWindow(hwnd)
+
+	style, err := GetWindowStyle(hwnd)
+	if err != nil {
+		t.Fatalf("GetWindowStyle() error = %v", err)
+	}
+	if style.Bits&WindowStyleBits(WS_POPUP) == 0 {
+		t.Fatalf("GetWindowStyle() = %v, want WS_POPUP set", style.Bits)
+	}
+
+	style.Bits |= WindowStyleBits(WS_BORDER)
+	if err := SetWindowStyle(hwnd, style); err != nil {
+		t.Fatalf("SetWindowStyle() error = %v", err)
+	}
+	if got, _ := GetWindowStyle(hwnd); got.Bits&WindowStyleBits(WS_BORDER) == 0 {
+		t.Errorf("GetWindowStyle() after SetWindowStyle = %v, want WS_BORDER set", got.Bits)
+	}
+}
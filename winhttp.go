@@ -0,0 +1,167 @@
+package win32utils
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	WINHTTP_ACCESS_TYPE_DEFAULT_PROXY uint32 = 0
+	WINHTTP_FLAG_SECURE               uint32 = 0x00800000
+
+	WINHTTP_OPTION_REDIRECT_POLICY                        uint32 = 88
+	WINHTTP_OPTION_REDIRECT_POLICY_NEVER                  uint32 = 0
+	WINHTTP_OPTION_REDIRECT_POLICY_DISALLOW_HTTPS_TO_HTTP uint32 = 1
+	WINHTTP_OPTION_REDIRECT_POLICY_ALWAYS                 uint32 = 2
+
+	WINHTTP_QUERY_STATUS_CODE uint32 = 19
+	WINHTTP_QUERY_FLAG_NUMBER uint32 = 0x20000000
+)
+
+const httpUserAgent = "win32utils"
+
+// HTTPGet performs an HTTP or HTTPS GET request with WinHTTP and returns the status code and the response body.
+// Redirects are always followed, and TLS and proxy settings are handled by the system.
+func HTTPGet(rawURL string, headers map[string]string) (statusCode int, body []byte, err error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var port uint64
+	var flags uint32
+	switch u.Scheme {
+	case "https":
+		port = 443
+		flags = WINHTTP_FLAG_SECURE
+	case "http":
+		port = 80
+	default:
+		return 0, nil, errors.New("win32utils: unsupported URL scheme " + strconv.Quote(u.Scheme))
+	}
+	if p := u.Port(); p != "" {
+		port, err = strconv.ParseUint(p, 10, 16)
+		if err != nil {
+			return 0, nil, err
+		}
+	}
+
+	agent, err := windows.UTF16PtrFromString(httpUserAgent)
+	if err != nil {
+		return 0, nil, err
+	}
+	host, err := windows.UTF16PtrFromString(u.Hostname())
+	if err != nil {
+		return 0, nil, err
+	}
+	verb, err := windows.UTF16PtrFromString("GET")
+	if err != nil {
+		return 0, nil, err
+	}
+	path, err := windows.UTF16PtrFromString(u.RequestURI())
+	if err != nil {
+		return 0, nil, err
+	}
+
+	session, _, err := Winhttp.NewProc("WinHttpOpen").Call(
+		uintptr(unsafe.Pointer(agent)),
+		uintptr(WINHTTP_ACCESS_TYPE_DEFAULT_PROXY),
+		0, 0, 0)
+	if session == 0 {
+		return 0, nil, err
+	}
+	defer winHttpCloseHandle(session)
+
+	connect, _, err := Winhttp.NewProc("WinHttpConnect").Call(session, uintptr(unsafe.Pointer(host)), uintptr(port), 0)
+	if connect == 0 {
+		return 0, nil, err
+	}
+	defer winHttpCloseHandle(connect)
+
+	request, _, err := Winhttp.NewProc("WinHttpOpenRequest").Call(
+		connect,
+		uintptr(unsafe.Pointer(verb)),
+		uintptr(unsafe.Pointer(path)),
+		0, 0, 0,
+		uintptr(flags))
+	if request == 0 {
+		return 0, nil, err
+	}
+	defer winHttpCloseHandle(request)
+
+	policy := WINHTTP_OPTION_REDIRECT_POLICY_ALWAYS
+	r1, _, err := Winhttp.NewProc("WinHttpSetOption").Call(
+		request,
+		uintptr(WINHTTP_OPTION_REDIRECT_POLICY),
+		uintptr(unsafe.Pointer(&policy)),
+		unsafe.Sizeof(policy))
+	if r1 == 0 {
+		return 0, nil, err
+	}
+
+	var header []uint16
+	for k, v := range headers {
+		u16, err := windows.UTF16FromString(k + ": " + v + "\r\n")
+		if err != nil {
+			return 0, nil, err
+		}
+		header = append(header, u16[:len(u16)-1]...)
+	}
+	var headerPtr *uint16
+	if len(header) > 0 {
+		headerPtr = &header[0]
+	}
+	r1, _, err = Winhttp.NewProc("WinHttpSendRequest").Call(
+		request,
+		uintptr(unsafe.Pointer(headerPtr)),
+		uintptr(len(header)),
+		0, 0, 0, 0)
+	if r1 == 0 {
+		return 0, nil, err
+	}
+
+	r1, _, err = Winhttp.NewProc("WinHttpReceiveResponse").Call(request, 0)
+	if r1 == 0 {
+		return 0, nil, err
+	}
+
+	var status uint32
+	size := uint32(unsafe.Sizeof(status))
+	r1, _, err = Winhttp.NewProc("WinHttpQueryHeaders").Call(
+		request,
+		uintptr(WINHTTP_QUERY_STATUS_CODE|WINHTTP_QUERY_FLAG_NUMBER),
+		0,
+		uintptr(unsafe.Pointer(&status)),
+		uintptr(unsafe.Pointer(&size)),
+		0)
+	if r1 == 0 {
+		return 0, nil, err
+	}
+
+	buf := make([]byte, 8192)
+	for {
+		var n uint32
+		r1, _, err = Winhttp.NewProc("WinHttpReadData").Call(
+			request,
+			uintptr(unsafe.Pointer(&buf[0])),
+			uintptr(len(buf)),
+			uintptr(unsafe.Pointer(&n)))
+		if r1 == 0 {
+			return int(status), body, err
+		}
+		if n == 0 {
+			break
+		}
+		body = append(body, buf[:n]...)
+	}
+
+	return int(status), body, nil
+}
+
+func winHttpCloseHandle(h uintptr) {
+	_, _, _ = Winhttp.NewProc("WinHttpCloseHandle").Call(h)
+}
@@ -0,0 +1,13 @@
+package win32utils
+
+import "testing"
+
+func TestHTTPGet(t *testing.T) {
+	status, _, err := HTTPGet("https://httpbin.org/status/200", map[string]string{"Accept": "*/*"})
+	if err != nil {
+		t.Skipf("HTTPGet() error = %v, network may be unavailable", err)
+	}
+	if status != 200 {
+		t.Errorf("HTTPGet() status = %d, want 200", status)
+	}
+}
@@ -0,0 +1,132 @@
+package win32utils
+
+import (
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// WndProc is an application-defined function that processes messages sent to a window.
+// WM_NCDESTROY is the last message it receives: the registration is removed afterwards
+// and the WndProc is never called for that window again.
+type WndProc func(hwnd windows.HWND, msg uint32, wParam, lParam uintptr) uintptr
+
+var (
+	wndProcMu     sync.RWMutex
+	wndProcByHWND = make(map[windows.HWND]WndProc)
+
+	// globalWndProcCallback is the only callback for all windows. windows.NewCallback allocates
+	// from a fixed number of callbacks that are never freed, so this package creates one per
+	// kind of callback and dispatches through a package map instead of creating one per use.
+	globalWndProcCallback = windows.NewCallback(globalWndProc)
+
+	// procDefWindowProcW is resolved once, in init, because globalWndProc calls it for
+	// almost every message and may run at any point in the life of the process.
+	procDefWindowProcW = User32.NewProc("DefWindowProcW")
+)
+
+func init() {
+	procDefWindowProcW.Addr()
+}
+
+// globalWndProc is the single window procedure installed on every window managed by
+// this package. It forwards messages to the WndProc registered for the window.
+func globalWndProc(hwnd windows.HWND, msg uint32, wParam, lParam uintptr) uintptr {
+	proc := getWndProc(hwnd)
+	if proc == nil {
+		return DefWindowProcW(hwnd, msg, wParam, lParam)
+	}
+
+	if msg == WM_NCDESTROY {
+		return callNCDestroy(proc, hwnd, wParam, lParam)
+	}
+	return proc(hwnd, msg, wParam, lParam)
+}
+
+// callNCDestroy delivers WM_NCDESTROY and then removes the registration of hwnd.
+// A panic in proc is recovered, because the window is gone either way and unwinding
+// through the system's window-destruction frames would crash the process.
+func callNCDestroy(proc WndProc, hwnd windows.HWND, wParam, lParam uintptr) (ret uintptr) {
+	defer func() {
+		deleteWndProc(hwnd)
+		if recover() != nil {
+			ret = 0
+		}
+	}()
+	return proc(hwnd, WM_NCDESTROY, wParam, lParam)
+}
+
+func getWndProc(hwnd windows.HWND) WndProc {
+	wndProcMu.RLock()
+	defer wndProcMu.RUnlock()
+	return wndProcByHWND[hwnd]
+}
+
+func deleteWndProc(hwnd windows.HWND) {
+	wndProcMu.Lock()
+	defer wndProcMu.Unlock()
+	delete(wndProcByHWND, hwnd)
+}
+
+// DefWindowProcW calls the default window procedure to provide default processing
+// for any window messages that an application does not process.
+func DefWindowProcW(hwnd windows.HWND, msg uint32, wParam, lParam uintptr) uintptr {
+	ret, _, _ := procDefWindowProcW.Call(uintptr(hwnd), uintptr(msg), wParam, lParam)
+	return ret
+}
+
+// ChainedWndProc is a message handler added with ChainWndProc. It reports whether it
+// handled the message; ret is only used when it did.
+type ChainedWndProc func(hwnd windows.HWND, msg uint32, wParam, lParam uintptr) (ret uintptr, handled bool)
+
+// ChainWndProc adds extra in front of the window procedure of hwnd.
+// extra sees every message first; when it reports the message as handled its result is
+// returned, otherwise the message is passed on to the existing handler.
+// Windows that are not yet managed by this package are subclassed, and their original
+// window procedure becomes the end of the chain.
+func ChainWndProc(hwnd windows.HWND, extra ChainedWndProc) {
+	wndProcMu.Lock()
+	defer wndProcMu.Unlock()
+
+	next, ok := wndProcByHWND[hwnd]
+	if !ok {
+		next = subclassWindow(hwnd)
+	}
+
+	wndProcByHWND[hwnd] = func(hwnd windows.HWND, msg uint32, wParam, lParam uintptr) uintptr {
+		if ret, handled := extra(hwnd, msg, wParam, lParam); handled {
+			return ret
+		}
+		return next(hwnd, msg, wParam, lParam)
+	}
+}
+
+// subclassWindow replaces the window procedure of hwnd with globalWndProc and
+// returns a WndProc that calls the previous one.
+func subclassWindow(hwnd windows.HWND) WndProc {
+	prev, _ := SetWindowLongPtrW(hwnd, GWLP_WNDPROC, globalWndProcCallback)
+	if prev == 0 || prev == globalWndProcCallback {
+		return DefWindowProcW
+	}
+
+	callWindowProc := User32.NewProc("CallWindowProcW")
+	return func(hwnd windows.HWND, msg uint32, wParam, lParam uintptr) uintptr {
+		ret, _, _ := callWindowProc.Call(prev, uintptr(hwnd), uintptr(msg), wParam, lParam)
+		return ret
+	}
+}
+
+// SetMinWindowSize constrains the size the user can resize hwnd to by
+// handling WM_GETMINMAXINFO. The message is still passed on, so that the window
+// procedure can adjust the other sizes.
+func SetMinWindowSize(hwnd windows.HWND, minWidth, minHeight int32) {
+	ChainWndProc(hwnd, func(hwnd windows.HWND, msg uint32, wParam, lParam uintptr) (uintptr, bool) {
+		if msg == WM_GETMINMAXINFO {
+			info := (*MinMaxInfo)(unsafe.Pointer(lParam))
+			info.PtMinTrackSize.X = minWidth
+			info.PtMinTrackSize.Y = minHeight
+		}
+		return 0, false
+	})
+}
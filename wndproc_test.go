@@ -0,0 +1,223 @@
+package win32utils
+
+import (
+	"runtime"
+	"slices"
+	"testing"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+func TestNCDestroyPanicCleansUp(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	hwnd, err := createManagedWindow("win32utils_test_ncdestroy", 0, 0, HWND_MESSAGE,
+		func(hwnd windows.HWND, msg uint32, wParam, lParam uintptr) uintptr {
+			if msg == WM_NCDESTROY {
+				panic("WM_NCDESTROY")
+			}
+			return DefWindowProcW(hwnd, msg, wParam, lParam)
+		})
+	if err != nil {
+		t.Fatalf("createManagedWindow() error = %v", err)
+	}
+
+	if err := DestroyWindow(hwnd); err != nil {
+		t.Fatalf("DestroyWindow() error = %v", err)
+	}
+	if getWndProc(hwnd) != nil {
+		t.Error("WndProc is still registered after WM_NCDESTROY panicked")
+	}
+}
+
+func TestSetMinWindowSize(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	hwnd, err := createManagedWindow("win32utils_test_minsize", 0, 0, HWND_MESSAGE, DefWindowProcW)
+	if err != nil {
+		t.Fatalf("createManagedWindow() error = %v", err)
+	}
+	defer DestroyWindow(hwnd)
+
+	SetMinWindowSize(hwnd, 320, 240)
+	var info MinMaxInfo
+	SendMessageW(hwnd, WM_GETMINMAXINFO, 0, uintptr(unsafe.Pointer(&info)))
+	if want := (POINT{320, 240}); info.PtMinTrackSize != want {
+		t.Errorf("PtMinTrackSize = %v, want %v", info.PtMinTrackSize, want)
+	}
+}
+
+func TestChainWndProc(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	var calls []string
+	hwnd, err := createManagedWindow("win32utils_test_chain", 0, 0, HWND_MESSAGE,
+		func(hwnd windows.HWND, msg uint32, wParam, lParam uintptr) uintptr {
+			if msg == WM_USER {
+				calls = append(calls, "base")
+			}
+			return DefWindowProcW(hwnd, msg, wParam, lParam)
+		})
+	if err != nil {
+		t.Fatalf("createManagedWindow() error = %v", err)
+	}
+	defer DestroyWindow(hwnd)
+
+	// A handler reports WM_USER as handled when wParam is non-zero, with a result of 0.
+	handler := func(name string) ChainedWndProc {
+		return func(hwnd windows.HWND, msg uint32, wParam, lParam uintptr) (uintptr, bool) {
+			if msg == WM_USER {
+				calls = append(calls, name)
+				return 0, wParam != 0
+			}
+			return 0, false
+		}
+	}
+	ChainWndProc(hwnd, handler("first"))
+	ChainWndProc(hwnd, handler("second"))
+
+	tests := []struct {
+		name   string
+		wParam uintptr
+		want   []string
+	}{
+		{"not handled", 0, []string{"second", "first", "base"}},
+		{"handled", 1, []string{"second"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = nil
+			SendMessageW(hwnd, WM_USER, tt.wParam, 0)
+			if !slices.Equal(calls, tt.want) {
+				t.Errorf("handlers called = %v, want %v", calls, tt.want)
+			}
+		})
+	}
+}
+
+func TestChainWndProcSubclass(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// The STATIC window procedure answers WM_GETDLGCODE with DLGC_STATIC; DefWindowProcW returns 0.
+	const (
+		wmGetDlgCode = 0x0087
+		dlgcStatic   = 0x0100
+	)
+
+	hwnd, err := CreateWindowExW(0, "STATIC", "", WS_POPUP, 0, 0, 10, 10, 0, 0, moduleHandle(), 0)
+	if err != nil {
+		t.Fatalf("CreateWindowExW() error = %v", err)
+	}
+	defer DestroyWindow(hwnd)
+
+	var seen bool
+	ChainWndProc(hwnd, func(hwnd windows.HWND, msg uint32, wParam, lParam uintptr) (uintptr, bool) {
+		if msg == wmGetDlgCode {
+			seen = true
+		}
+		return 0, false
+	})
+
+	if proc, _ := GetWindowLongPtrW(hwnd, GWLP_WNDPROC); proc != globalWndProcCallback {
+		t.Errorf("GWLP_WNDPROC = 0x%X, want globalWndProc", proc)
+	}
+	if got := SendMessageW(hwnd, wmGetDlgCode, 0, 0); got&dlgcStatic == 0 {
+		t.Errorf("WM_GETDLGCODE = 0x%X, want DLGC_STATIC from the original window procedure", got)
+	}
+	if !seen {
+		t.Error("chained handler did not see WM_GETDLGCODE")
+	}
+}
+
+// newBenchWindow creates a message-only window whose WndProc handles WM_USER without calling DefWindowProcW.
+// The caller must be locked to its OS thread.
+func newBenchWindow(b *testing.B) windows.HWND {
+	hwnd, err := createManagedWindow("win32utils_bench_window", 0, 0, HWND_MESSAGE,
+		func(hwnd windows.HWND, msg uint32, wParam, lParam uintptr) uintptr {
+			if msg == WM_USER {
+				return 1
+			}
+			return DefWindowProcW(hwnd, msg, wParam, lParam)
+		})
+	if err != nil {
+		b.Fatalf("createManagedWindow() error = %v", err)
+	}
+	b.Cleanup(func() { DestroyWindow(hwnd) })
+	return hwnd
+}
+
+func BenchmarkGlobalWndProc(b *testing.B) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	hwnd := newBenchWindow(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		globalWndProc(hwnd, WM_USER, 0, 0)
+	}
+}
+
+func BenchmarkDispatchMessageW(b *testing.B) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	msg := MSG{Hwnd: newBenchWindow(b), Message: WM_USER}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		DispatchMessageW(&msg)
+	}
+}
+
+func BenchmarkGetMessageW_PeekMessage_roundtrip(b *testing.B) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	hwnd := newBenchWindow(b)
+
+	var msg MSG
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := PostMessageW(hwnd, WM_USER, 0, 0); err != nil {
+			b.Fatalf("PostMessageW() error = %v", err)
+		}
+		if !PeekMessageW(&msg, hwnd, 0, 0, PM_REMOVE) {
+			b.Fatal("PeekMessageW() found no message")
+		}
+		DispatchMessageW(&msg)
+	}
+}
+
+func TestSetRawInputHandler(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// A synthetic WM_INPUT has no real HRAWINPUT behind it, so stub out GetRawInputData.
+	defer func(orig func(uintptr) (RAWINPUT, error)) { readRawInput = orig }(readRawInput)
+	readRawInput = func(uintptr) (RAWINPUT, error) {
+		return RAWINPUT{Header: RAWINPUTHEADER{DwType: RIM_TYPEKEYBOARD}}, nil
+	}
+
+	hwnd, err := createManagedWindow("win32utils_test_rawinput", 0, 0, HWND_MESSAGE, DefWindowProcW)
+	if err != nil {
+		t.Fatalf("createManagedWindow() error = %v", err)
+	}
+	defer DestroyWindow(hwnd)
+
+	var got *RAWINPUT
+	SetRawInputHandler(hwnd, func(raw RAWINPUT) { got = &raw })
+	SendMessageW(hwnd, WM_INPUT, 0, 0)
+
+	if got == nil {
+		t.Fatal("raw input handler was not called")
+	}
+	if got.Header.DwType != RIM_TYPEKEYBOARD {
+		t.Errorf("Header.DwType = %d, want RIM_TYPEKEYBOARD", got.Header.DwType)
+	}
+}